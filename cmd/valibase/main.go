@@ -0,0 +1,236 @@
+// Command valibase generates TypeScript types and Valibot schemas outside of a running app.
+//
+// Usage:
+//
+//	valibase -data ./pb_data -out ./src/database.ts
+//	valibase -data ./pb_data -dump-schema ./schema.json
+//	valibase -schema ./schema.json -out ./src/database.ts
+//	valibase -migrations ./pb_migrations -out ./src/database.ts
+//	valibase -data ./pb_data -out ./src/database.ts -changelog -previous-schema ./schema.json
+//	valibase -data ./pb_data -out ./src/database.ts -messages ./messages.sv.json
+//	valibase -data ./pb_data -out ./docs/collections.mmd -format mermaid
+//	valibase -data ./pb_data -out ./src/database.ts -docs ./docs/collections.md
+//	valibase -data ./pb_data -out ./src/mocks.ts -format mocks -types-import ./database
+//	valibase -data ./pb_data -seed 20
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/pocketbase/pocketbase/core"
+	_ "github.com/pocketbase/pocketbase/migrations"
+	"github.com/zenaxo/valibase/generator"
+	"github.com/zenaxo/valibase/generator/migrations"
+	"github.com/zenaxo/valibase/seed"
+)
+
+type config struct {
+	dataDir        string
+	outPath        string
+	dumpPath       string
+	schemaPath     string
+	migrationsDir  string
+	messagesPath   string
+	format         string
+	typesImport    string
+	seedCount      int
+	seedPassword   string
+	failOnBreaking bool
+	opts           generator.Options
+}
+
+func main() {
+	var c config
+
+	flag.StringVar(&c.dataDir, "data", "pb_data", "PocketBase data directory to read the collections from")
+	flag.StringVar(&c.outPath, "out", "", "path of the generated file")
+	flag.StringVar(&c.dumpPath, "dump-schema", "", `write the collection model as JSON to this path ("-" for stdout)`)
+	flag.StringVar(&c.schemaPath, "schema", "", "generate from a JSON snapshot instead of the data directory")
+	flag.StringVar(&c.migrationsDir, "migrations", "", "generate by replaying the JS migrations in this directory instead of the data directory")
+	flag.BoolVar(&c.opts.EmbedSchema, "embed-schema", false, "embed the collection model in the generated file header")
+	flag.BoolVar(&c.opts.Changelog, "changelog", false, "print the changes since the previous generation, needs -embed-schema or -previous-schema")
+	flag.StringVar(&c.opts.PreviousSchema, "previous-schema", "", "JSON snapshot to compare against (default: the header of -out)")
+	flag.StringVar(&c.messagesPath, "messages", "", "JSON object of validation messages by rule key, overriding the defaults")
+	flag.BoolVar(&c.opts.MessageKeys, "message-keys", false, "emit message keys for runtime lookup instead of literal messages")
+	flag.BoolVar(&c.opts.RuleAwareClient, "rule-aware", false, "hide create, update and delete from the typed client for superuser-only collections")
+	flag.BoolVar(&c.opts.Admin, "admin", false, "also emit superuser schemas and types including hidden fields")
+	flag.StringVar(&c.opts.DocsPath, "docs", "", "also write the Markdown data dictionary of the collections to this path")
+	flag.StringVar(&c.format, "format", "ts", "output format of -out: ts, mocks, mermaid or dbml")
+	flag.StringVar(&c.typesImport, "types-import", "./database", "module the mocks import the generated types from")
+	flag.IntVar(&c.seedCount, "seed", 0, "insert this many fake records into every collection of the data directory")
+	flag.StringVar(&c.seedPassword, "seed-password", "", "password of the seeded auth records (default: random)")
+	flag.BoolVar(&c.failOnBreaking, "fail-on-breaking", false, "exit with an error if the changelog contains breaking changes")
+	flag.Parse()
+
+	if err := run(c); err != nil {
+		fmt.Fprintln(os.Stderr, "valibase:", err)
+		os.Exit(1)
+	}
+}
+
+func run(c config) error {
+	if c.outPath == "" && c.dumpPath == "" && c.seedCount <= 0 {
+		return errors.New("nothing to do, set -out, -dump-schema and/or -seed")
+	}
+	if c.failOnBreaking {
+		c.opts.Changelog = true
+	}
+
+	switch c.format {
+	case "ts":
+	case "mocks":
+		c.opts.Emitter = generator.MockEmitter(c.typesImport, c.opts)
+	case "mermaid":
+		c.opts.Emitter = generator.MermaidEmitter()
+	case "dbml":
+		c.opts.Emitter = generator.DBMLEmitter()
+	default:
+		return fmt.Errorf("unknown format %q, use ts, mocks, mermaid or dbml", c.format)
+	}
+
+	if c.messagesPath != "" {
+		messages, err := readMessages(c.messagesPath)
+		if err != nil {
+			return fmt.Errorf("messages: %w", err)
+		}
+		c.opts.Messages = messages
+	}
+
+	if c.schemaPath != "" && c.migrationsDir != "" {
+		return errors.New("-schema cannot be combined with -migrations")
+	}
+	if c.seedCount > 0 && (c.schemaPath != "" || c.migrationsDir != "") {
+		return errors.New("-seed needs the data directory, it cannot be combined with -schema or -migrations")
+	}
+
+	if c.migrationsDir != "" {
+		if c.dumpPath != "" {
+			return errors.New("-dump-schema cannot be combined with -migrations")
+		}
+
+		res, err := migrations.GenerateTypesWithResult(c.migrationsDir, c.outPath, c.opts)
+		if err != nil {
+			return err
+		}
+		return report(c.outPath, res, c.failOnBreaking)
+	}
+
+	if c.schemaPath != "" {
+		if c.dumpPath != "" {
+			return errors.New("-dump-schema cannot be combined with -schema")
+		}
+
+		f, err := os.Open(c.schemaPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		res, err := generator.GenerateTypesFromSchemaWithResult(f, c.outPath, c.opts)
+		if err != nil {
+			return err
+		}
+		return report(c.outPath, res, c.failOnBreaking)
+	}
+
+	if _, err := os.Stat(c.dataDir); err != nil {
+		return fmt.Errorf("data directory: %w", err)
+	}
+
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: c.dataDir})
+	if err := app.Bootstrap(); err != nil {
+		return fmt.Errorf("bootstrap: %w", err)
+	}
+	defer app.ResetBootstrapState()
+
+	// generate first, so -changelog can compare against the snapshot before it is overwritten
+	if c.outPath != "" {
+		res, err := generator.GenerateTypesWithResult(app, c.outPath, c.opts)
+		if err != nil {
+			return err
+		}
+		if err := report(c.outPath, res, c.failOnBreaking); err != nil {
+			return err
+		}
+	}
+
+	if c.dumpPath != "" {
+		if err := dumpSchema(app, c.dumpPath); err != nil {
+			return err
+		}
+	}
+
+	if c.seedCount > 0 {
+		res, err := seed.Insert(app, seed.Options{Count: c.seedCount, Password: c.seedPassword})
+		if err != nil {
+			return err
+		}
+		for _, name := range slices.Sorted(maps.Keys(res.IDs)) {
+			fmt.Fprintf(os.Stderr, "valibase: seeded %d %s records\n", len(res.IDs[name]), name)
+		}
+	}
+
+	return nil
+}
+
+func report(outPath string, res *generator.Result, failOnBreaking bool) error {
+	fmt.Fprintf(os.Stderr, "valibase: %s %s\n", outPath, res.Status)
+	if res.DocsStatus != "" {
+		fmt.Fprintf(os.Stderr, "valibase: docs %s\n", res.DocsStatus)
+	}
+
+	if res.Changelog == nil {
+		return nil
+	}
+
+	if len(res.Changelog.Changes) == 0 {
+		fmt.Fprintln(os.Stderr, "valibase: no schema changes")
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, res.Changelog.String())
+
+	if failOnBreaking && res.Changelog.HasBreaking() {
+		return fmt.Errorf("%d breaking schema change(s)", len(res.Changelog.Breaking()))
+	}
+
+	return nil
+}
+
+func dumpSchema(app core.App, path string) error {
+	if path == "-" {
+		return generator.DumpSchema(app, os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := generator.DumpSchema(app, f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func readMessages(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"github.com/zenaxo/valibase/internal/erd"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// MermaidEmitter returns an emitter rendering the collections as a Mermaid erDiagram,
+// with field types, keys, required flags and relation cardinality.
+//
+//	generator.GenerateTypes(app, "docs/collections.mmd", generator.Options{Emitter: generator.MermaidEmitter()})
+func MermaidEmitter() schema.Emitter {
+	return erd.Mermaid{}
+}
+
+// DBMLEmitter returns an emitter rendering the collections as DBML, e.g. for dbdiagram.io.
+func DBMLEmitter() schema.Emitter {
+	return erd.DBML{}
+}
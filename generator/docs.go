@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/zenaxo/valibase/internal/docs"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// GenerateDocs writes a Markdown reference of all PocketBase collections to outPath,
+// with a section per collection listing its access rules and its fields with their
+// types, constraints, select values and relation targets.
+//
+// To regenerate it together with the types, set Options.DocsPath instead.
+func GenerateDocs(app core.App, outPath string) (Status, error) {
+	colls, err := app.FindAllCollections()
+	if err != nil {
+		return "", fmt.Errorf("GenerateDocs: FindAllCollections: %w", err)
+	}
+
+	status, err := writeDocs(schema.BuildCollections(colls), outPath)
+	if err != nil {
+		return "", fmt.Errorf("GenerateDocs: %w", err)
+	}
+
+	return status, nil
+}
+
+func writeDocs(colls []schema.Collection, path string) (Status, error) {
+	out, err := docs.Markdown{}.Emit(colls)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("mkdir %s: %w", filepath.Dir(path), err)
+	}
+
+	status, err := writeFile(path, out)
+	if err != nil {
+		return "", fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return status, nil
+}
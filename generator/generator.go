@@ -1,12 +1,16 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/zenaxo/valibase/internal/gen"
+	"github.com/zenaxo/valibase/internal/valibot"
+	"github.com/zenaxo/valibase/schema"
 )
 
 // Options controls how TypeScript types are generated.
@@ -15,11 +19,205 @@ type Options struct {
 	// OutPath is optional if you prefer to pass the output path to GenerateTypes directly.
 	// If you set OutPath, you can pass an empty outPath to GenerateTypes.
 	OutPath string
+
+	// Emitter renders the collections into the written file.
+	// If nil, TypeScript types and Valibot schemas are generated.
+	Emitter schema.Emitter
+
+	// EmbedSchema embeds the collection model in the header of the generated TypeScript file,
+	// so the next generation can compare against it without a separate snapshot.
+	EmbedSchema bool
+
+	// Changelog compares the collections with the previous model and reports the
+	// changes in Result.Changelog.
+	//
+	// The previous model is read from PreviousSchema if set, otherwise from the
+	// header of the existing output file (see EmbedSchema), so one of them is required.
+	// Result.Changelog is nil on the first generation with EmbedSchema.
+	Changelog bool
+
+	// PreviousSchema is the path of a snapshot written by DumpSchema.
+	PreviousSchema string
+
+	// Naming derives the type and schema names of each collection.
+	// If nil, DefaultNaming is used.
+	Naming Naming
+
+	// Names overrides the singular name of a collection, which the type and schema
+	// names are derived from, e.g. {"news": "NewsItem"} generates NewsItem and newsItemResponse.
+	Names map[string]string
+
+	// Messages overrides the validation messages by rule key, e.g.
+	// {"minLength": "Minst {min} tecken"}. See DefaultMessages for the keys and their placeholders.
+	Messages map[string]string
+
+	// MessageKeys emits message keys instead of literal messages, resolved at runtime
+	// by the function passed to setMessageResolver in the generated file.
+	MessageKeys bool
+
+	// Fields overrides the generated schemas of fields, keyed by "collection.field",
+	// for constraints PocketBase can't express (e.g. a lowercase slug).
+	Fields map[string]FieldOverride
+
+	// Checks are cross-field validations keyed by collection name, piped after the
+	// create and update schemas of the collection.
+	Checks map[string][]Check
+
+	// Templates replaces the built-in imports, helpers and tail sections of the
+	// generated file, see Templates.
+	Templates Templates
+
+	// RuleAwareClient omits create, update and delete from the typed client (and batches)
+	// for collections whose rule is superuser only (nil), turning those calls into
+	// compile errors. TypedAdminPocketBase keeps every method.
+	RuleAwareClient bool
+
+	// Admin also emits superuser schemas including hidden fields (e.g. postAdminResponse),
+	// with the AdminResponseTypes map and the TypedAdminPocketBase client type.
+	Admin bool
+
+	// DocsPath also writes the Markdown data dictionary (see GenerateDocs) to this path
+	// on every generation, so the Regenerator hooks keep it current with the types.
+	DocsPath string
+}
+
+// Templates replaces sections of the generated TypeScript file. A nil template
+// keeps the built-in one, an empty one drops the section.
+//
+// Templates are rendered with text/template. The data has the collection model as
+// .Collections, and the functions typeName and schemaName return the generated
+// names of a collection (e.g. {{typeName "posts"}} -> Post). The built-in helpers
+// use {{msg "key"}} for the messages of the catalog, see DefaultTemplates.
+type Templates struct {
+	// Imports is the header of the file, with the pocketbase and valibot imports.
+	Imports *Template
+
+	// Helpers holds the shared schemas and types, emitted before the collections.
+	Helpers *Template
+
+	// Tail holds the typed client, emitted after the registry.
+	Tail *Template
+}
+
+// Template is the text of a template, or the file at Path in FS if FS is set.
+type Template struct {
+	Text string
+	FS   fs.FS
+	Path string
+}
+
+// DefaultTemplates returns the built-in templates keyed by "imports", "helpers" and "tail",
+// a starting point for replacements.
+func DefaultTemplates() map[string]string {
+	return gen.DefaultTemplates()
+}
+
+// FieldOverride replaces or extends the Valibot schemas generated for a field.
+// Schemas and actions are TypeScript expressions using the `v` namespace.
+type FieldOverride struct {
+	// Type replaces the field type the schemas are generated from, e.g. for
+	// view columns PocketBase infers as json although the SQL returns a number.
+	Type schema.FieldType
+
+	// Response replaces the response schema of the field.
+	Response string
+
+	// Input replaces the input schema of the field.
+	Input string
+
+	// Append pipes actions after the input schema, e.g. "v.toLowerCase()".
+	// For optional fields they are piped inside v.optional, so they never receive
+	// undefined. With Input set, they are piped after it as is.
+	Append []string
+}
+
+// Check is a cross-field validation, reported on Field through v.forward:
+//
+//	v.forward(v.check(Fn, Message), [Field])
+//
+// Update inputs are partial, so Fn should allow missing fields.
+type Check struct {
+	// Field is the field the issue is reported on.
+	Field string
+
+	// Fn is the TypeScript check function receiving the whole input,
+	// e.g. "(i) => !i.end || !i.start || i.end > i.start".
+	Fn string
+
+	// Message is the issue message. It is part of the message catalog under Key,
+	// so it can be overridden through Options.Messages and looked up with MessageKeys.
+	Message string
+
+	// Key is the message key of Message, "collection.field" if empty.
+	// Set it to tell several checks of a field apart.
+	Key string
+}
+
+// Naming derives the identifiers of a collection in the generated TypeScript.
+//
+// Generation fails if two collections end up with the same identifier
+// (e.g. "post" and "posts" both generating Post), use Options.Names to resolve it.
+type Naming interface {
+	// Singular returns the singular form of a collection name, e.g. "todos" -> "todo".
+	Singular(collectionName string) string
+	// Pascal converts s to PascalCase, used for types and the Collections keys.
+	Pascal(s string) string
+	// Camel converts s to lowerCamelCase, used for the schema constants.
+	Camel(s string) string
+}
+
+// DefaultMessages returns the built-in validation messages keyed by rule,
+// a starting point for a translated catalog.
+func DefaultMessages() map[string]string {
+	return valibot.DefaultMessages()
+}
+
+// DefaultNaming returns the built-in Naming, useful to override only some of its methods.
+func DefaultNaming() Naming {
+	return gen.DefaultNaming{}
+}
+
+// Result describes a successful generation.
+type Result struct {
+	// Status reports whether the output file was written or already up to date.
+	Status Status
+
+	// Changelog lists the changes since the previous model.
+	// It is nil unless Options.Changelog is set and a previous model was found.
+	Changelog *schema.Changelog
+
+	// DocsStatus reports whether the docs file was written, it is empty unless Options.DocsPath is set.
+	DocsStatus Status
 }
 
 // GenerateTypes generates TypeScript types for all PocketBase collections and writes them to outPath.
 // If opts.OutPath is set and outPath is empty, opts.OutPath will be used.
+// If opts.Emitter is set, its output is written instead.
+//
+// The file is replaced atomically and left untouched if its content did not change.
+// Use GenerateTypesWithResult to know whether it was written or to read the changelog.
 func GenerateTypes(app core.App, outPath string, opts ...Options) error {
+	_, err := GenerateTypesWithResult(app, outPath, opts...)
+	return err
+}
+
+// GenerateTypesWithResult works like GenerateTypes and also reports the result of the generation.
+func GenerateTypesWithResult(app core.App, outPath string, opts ...Options) (*Result, error) {
+	colls, err := app.FindAllCollections()
+	if err != nil {
+		return nil, fmt.Errorf("GenerateTypes: FindAllCollections: %w", err)
+	}
+
+	return generate("GenerateTypes", schema.BuildCollections(colls), outPath, opts)
+}
+
+// GenerateTypesFromCollections works like GenerateTypesWithResult but takes an already
+// built collection model, e.g. from migrations.Schema or a custom source.
+func GenerateTypesFromCollections(colls []schema.Collection, outPath string, opts ...Options) (*Result, error) {
+	return generate("GenerateTypesFromCollections", colls, outPath, opts)
+}
+
+func generate(op string, colls []schema.Collection, outPath string, opts []Options) (*Result, error) {
 	var o Options
 	if len(opts) > 0 {
 		o = opts[0]
@@ -28,23 +226,126 @@ func GenerateTypes(app core.App, outPath string, opts ...Options) error {
 		outPath = o.OutPath
 	}
 	if outPath == "" {
-		return fmt.Errorf("GenerateTypes: outPath is required")
+		return nil, fmt.Errorf("%s: outPath is required", op)
 	}
 
-	colls, err := app.FindAllCollections()
-	if err != nil {
-		return fmt.Errorf("GenerateTypes: FindAllCollections: %w", err)
+	res := &Result{}
+
+	// custom emitters don't embed the model, only the TypeScript header has it
+	if o.Changelog && o.PreviousSchema == "" && (!o.EmbedSchema || o.Emitter != nil) {
+		return nil, fmt.Errorf("%s: Changelog needs PreviousSchema or EmbedSchema with the TypeScript output", op)
+	}
+
+	if o.Changelog {
+		prev, ok, err := previousSchema(o.PreviousSchema, outPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: previous schema: %w", op, err)
+		}
+		if ok {
+			cl := schema.Diff(prev, colls)
+			res.Changelog = &cl
+		}
 	}
 
-	ts := gen.GenerateTS(gen.BuildCollections(colls))
+	out, err := emit(colls, o)
+	if err != nil {
+		return nil, fmt.Errorf("%s: emit: %w", op, err)
+	}
 
 	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
-		return fmt.Errorf("GenerateTypes: mkdir %s: %w", filepath.Dir(outPath), err)
+		return nil, fmt.Errorf("%s: mkdir %s: %w", op, filepath.Dir(outPath), err)
+	}
+
+	res.Status, err = writeFile(outPath, out)
+	if err != nil {
+		return nil, fmt.Errorf("%s: write %s: %w", op, outPath, err)
+	}
+
+	if o.DocsPath != "" {
+		res.DocsStatus, err = writeDocs(colls, o.DocsPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: docs: %w", op, err)
+		}
+	}
+
+	return res, nil
+}
+
+// emit renders colls with o.Emitter or the default TypeScript emitter.
+func emit(colls []schema.Collection, o Options) ([]byte, error) {
+	emitter := o.Emitter
+	if emitter == nil {
+		emitter = gen.Emitter{Options: genOptions(o)}
+	}
+
+	return emitter.Emit(colls)
+}
+
+func genOptions(o Options) gen.Options {
+	return gen.Options{
+		EmbedSchema: o.EmbedSchema,
+		Naming:      o.Naming,
+		Names:       o.Names,
+		Messages:    o.Messages,
+		MessageKeys: o.MessageKeys,
+		Fields:      fieldOverrides(o.Fields),
+		Checks:      checks(o.Checks),
+		Admin:       o.Admin,
+		RuleAware:   o.RuleAwareClient,
+		Templates: gen.Templates{
+			Imports: (*gen.Template)(o.Templates.Imports),
+			Helpers: (*gen.Template)(o.Templates.Helpers),
+			Tail:    (*gen.Template)(o.Templates.Tail),
+		},
+	}
+}
+
+func fieldOverrides(fields map[string]FieldOverride) map[string]gen.FieldOverride {
+	if fields == nil {
+		return nil
+	}
+
+	out := make(map[string]gen.FieldOverride, len(fields))
+	for key, f := range fields {
+		out[key] = gen.FieldOverride(f)
+	}
+	return out
+}
+
+func checks(checks map[string][]Check) map[string][]gen.Check {
+	if checks == nil {
+		return nil
+	}
+
+	out := make(map[string][]gen.Check, len(checks))
+	for collection, cs := range checks {
+		for _, c := range cs {
+			out[collection] = append(out[collection], gen.Check(c))
+		}
+	}
+	return out
+}
+
+// previousSchema loads the model to compare against, ok is false on the first generation.
+func previousSchema(snapshotPath, outPath string) (colls []schema.Collection, ok bool, err error) {
+	path := outPath
+	if snapshotPath != "" {
+		path = snapshotPath
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
 	}
+	defer f.Close()
 
-	if err := os.WriteFile(outPath, []byte(ts), 0o644); err != nil {
-		return fmt.Errorf("GenerateTypes: write %s: %w", outPath, err)
+	if snapshotPath != "" {
+		colls, err = schema.ReadJSON(f)
+		return colls, err == nil, err
 	}
 
-	return nil
+	return gen.ReadSchemaHeader(f)
 }
@@ -0,0 +1,6 @@
+// Package migrations generates the TypeScript output from the migrations of a project
+// instead of its data directory, e.g. in CI where no pb_data exists.
+//
+// It is kept out of the generator package because replaying the JS migrations pulls
+// in the jsvm plugin, which only the projects using this package should have to build.
+package migrations
@@ -0,0 +1,92 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/core"
+	_ "github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/plugins/jsvm"
+	"github.com/zenaxo/valibase/generator"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// appMigrationsMu guards core.AppMigrations, which the JS migrations are registered into.
+var appMigrationsMu sync.Mutex
+
+// Schema replays migrations against a temporary PocketBase app and returns the
+// resulting collection model. The temporary data directory is removed afterwards.
+//
+// JS migrations are loaded from migrationsDir (it may be empty to skip them).
+// Go migrations can't be loaded from a directory, only the ones compiled into the
+// calling binary are applied: they register themselves in core.AppMigrations when
+// their package is imported (e.g. `_ "yourapp/migrations"`), so the valibase CLI
+// replays JS migrations only.
+func Schema(migrationsDir string) ([]schema.Collection, error) {
+	appMigrationsMu.Lock()
+	defer appMigrationsMu.Unlock()
+
+	// JS migrations are registered globally, restore the list so repeated calls don't apply them twice
+	registered := slices.Clone(core.AppMigrations.Items())
+	defer func() {
+		core.AppMigrations = core.MigrationsList{}
+		for _, m := range registered {
+			core.AppMigrations.Add(m)
+		}
+	}()
+
+	tmpDir, err := os.MkdirTemp("", "valibase-migrations-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	app := core.NewBaseApp(core.BaseAppConfig{DataDir: filepath.Join(tmpDir, "pb_data")})
+
+	if migrationsDir != "" {
+		err := jsvm.Register(app, jsvm.Config{
+			MigrationsDir: migrationsDir,
+			// keep the app hooks of the project out of the throwaway app
+			HooksDir: filepath.Join(tmpDir, "pb_hooks"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("load migrations: %w", err)
+		}
+	}
+
+	if err := app.Bootstrap(); err != nil {
+		return nil, fmt.Errorf("bootstrap: %w", err)
+	}
+	defer app.ResetBootstrapState()
+
+	if err := app.RunAllMigrations(); err != nil {
+		return nil, fmt.Errorf("run migrations: %w", err)
+	}
+
+	colls, err := app.FindAllCollections()
+	if err != nil {
+		return nil, fmt.Errorf("FindAllCollections: %w", err)
+	}
+
+	return schema.BuildCollections(colls), nil
+}
+
+// GenerateTypes works like generator.GenerateTypes but builds the collections
+// by replaying migrations, see Schema for which migrations are applied.
+func GenerateTypes(migrationsDir, outPath string, opts ...generator.Options) error {
+	_, err := GenerateTypesWithResult(migrationsDir, outPath, opts...)
+	return err
+}
+
+// GenerateTypesWithResult works like GenerateTypes and also reports the result of the generation.
+func GenerateTypesWithResult(migrationsDir, outPath string, opts ...generator.Options) (*generator.Result, error) {
+	colls, err := Schema(migrationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("migrations.GenerateTypes: %w", err)
+	}
+
+	return generator.GenerateTypesFromCollections(colls, outPath, opts...)
+}
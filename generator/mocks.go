@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"github.com/zenaxo/valibase/internal/gen"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// MockEmitter returns an emitter rendering a make<Type>(overrides) factory per collection,
+// building records accepted by the generated response schemas, e.g. for tests and Storybook.
+//
+// importPath is the module the generated types are imported from, relative to the mocks file
+// (e.g. "./database"). types are the Options the types are generated with, so the names and
+// field type overrides match.
+//
+// Values come from a seeded PRNG, call seedMocks(n) in the setup of your tests for stable snapshots.
+func MockEmitter(importPath string, types ...Options) schema.Emitter {
+	var o Options
+	if len(types) > 0 {
+		o = types[0]
+	}
+
+	return gen.MockEmitter{Options: genOptions(o), Import: importPath}
+}
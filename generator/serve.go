@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// ServeOptions configures the routes registered by Serve.
+type ServeOptions struct {
+	// Prefix of the routes, defaults to "/api/valibase".
+	Prefix string
+
+	// Token grants access to the routes without superuser auth, sent as the
+	// "X-Valibase-Token" header. It is not accepted in the query, where it would end up in logs.
+	// If empty, only superusers can access the routes.
+	Token string
+
+	// Options used to generate the served types, OutPath is ignored.
+	Options Options
+}
+
+// Serve registers routes serving the current generated output:
+//
+//	GET {Prefix}/types.ts     the generated types (or the output of Options.Emitter)
+//	GET {Prefix}/schema.json  the collection model as written by DumpSchema
+//
+// Responses are cached until a collection is created, updated or deleted and
+// carry an ETag, so clients can poll cheaply with If-None-Match.
+func Serve(app core.App, opts ...ServeOptions) {
+	var o ServeOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Prefix == "" {
+		o.Prefix = "/api/valibase"
+	}
+	o.Prefix = strings.TrimSuffix(o.Prefix, "/")
+
+	c := &servedCache{}
+
+	invalidate := func(e *core.CollectionEvent) error {
+		c.reset()
+		return e.Next()
+	}
+	app.OnCollectionAfterCreateSuccess().BindFunc(invalidate)
+	app.OnCollectionAfterUpdateSuccess().BindFunc(invalidate)
+	app.OnCollectionAfterDeleteSuccess().BindFunc(invalidate)
+
+	app.OnServe().BindFunc(func(se *core.ServeEvent) error {
+		se.Router.GET(o.Prefix+"/types.ts", func(e *core.RequestEvent) error {
+			return serveCached(e, o, &c.types, "text/plain; charset=utf-8", func(colls []schema.Collection) ([]byte, error) {
+				return emit(colls, o.Options)
+			})
+		})
+
+		se.Router.GET(o.Prefix+"/schema.json", func(e *core.RequestEvent) error {
+			return serveCached(e, o, &c.schema, "application/json", func(colls []schema.Collection) ([]byte, error) {
+				var buf bytes.Buffer
+				err := schema.WriteJSON(&buf, colls)
+				return buf.Bytes(), err
+			})
+		})
+
+		return se.Next()
+	})
+}
+
+type cachedBody struct {
+	mu   sync.Mutex
+	body []byte
+	etag string
+}
+
+type servedCache struct {
+	types  cachedBody
+	schema cachedBody
+}
+
+func (c *servedCache) reset() {
+	for _, b := range []*cachedBody{&c.types, &c.schema} {
+		b.mu.Lock()
+		b.body, b.etag = nil, ""
+		b.mu.Unlock()
+	}
+}
+
+func serveCached(
+	e *core.RequestEvent,
+	o ServeOptions,
+	cached *cachedBody,
+	contentType string,
+	render func([]schema.Collection) ([]byte, error),
+) error {
+	if !authorized(e, o.Token) {
+		return e.UnauthorizedError("Superuser or valibase token required.", nil)
+	}
+
+	cached.mu.Lock()
+	if cached.body == nil {
+		colls, err := e.App.FindAllCollections()
+		if err != nil {
+			cached.mu.Unlock()
+			return e.InternalServerError("Failed to load the collections.", err)
+		}
+
+		body, err := render(schema.BuildCollections(colls))
+		if err != nil {
+			cached.mu.Unlock()
+			return e.InternalServerError("Failed to generate the output.", err)
+		}
+
+		sum := sha256.Sum256(body)
+		cached.body = body
+		cached.etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+	body, etag := cached.body, cached.etag
+	cached.mu.Unlock()
+
+	e.Response.Header().Set("ETag", etag)
+	e.Response.Header().Set("Cache-Control", "no-cache")
+
+	if e.Request.Header.Get("If-None-Match") == etag {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.Blob(http.StatusOK, contentType, body)
+}
+
+func authorized(e *core.RequestEvent, token string) bool {
+	if e.HasSuperuserAuth() {
+		return true
+	}
+	if token == "" {
+		return false
+	}
+
+	got := e.Request.Header.Get("X-Valibase-Token")
+
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// DumpSchema writes the collection model of app to w as canonical JSON.
+//
+// The snapshot can be checked in and later passed to GenerateTypesFromSchema
+// to generate the same output without a running PocketBase app.
+func DumpSchema(app core.App, w io.Writer) error {
+	colls, err := app.FindAllCollections()
+	if err != nil {
+		return fmt.Errorf("DumpSchema: FindAllCollections: %w", err)
+	}
+
+	if err := schema.WriteJSON(w, schema.BuildCollections(colls)); err != nil {
+		return fmt.Errorf("DumpSchema: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateTypesFromSchema works like GenerateTypes but reads the collections
+// from a snapshot written by DumpSchema instead of a live app.
+func GenerateTypesFromSchema(r io.Reader, outPath string, opts ...Options) error {
+	_, err := GenerateTypesFromSchemaWithResult(r, outPath, opts...)
+	return err
+}
+
+// GenerateTypesFromSchemaWithResult works like GenerateTypesFromSchema and also
+// reports the result of the generation.
+func GenerateTypesFromSchemaWithResult(r io.Reader, outPath string, opts ...Options) (*Result, error) {
+	colls, err := schema.ReadJSON(r)
+	if err != nil {
+		return nil, fmt.Errorf("GenerateTypesFromSchema: %w", err)
+	}
+
+	return generate("GenerateTypesFromSchema", colls, outPath, opts)
+}
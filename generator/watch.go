@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// DefaultDebounce is the debounce used by NewRegenerator when none is given.
+const DefaultDebounce = 300 * time.Millisecond
+
+// Regenerator regenerates the types when collections change.
+//
+// Bursts of collection create, update and delete events (e.g. a migration or an
+// import touching several collections) are coalesced into a single generation,
+// which runs once no new event arrived for the debounce duration. Generation runs
+// on its own goroutine, so saving a collection never waits for it.
+type Regenerator struct {
+	app      core.App
+	outPath  string
+	debounce time.Duration
+	opts     Options
+
+	mu    sync.Mutex
+	timer *time.Timer
+
+	// genMu serializes generations, a new burst may start while the previous one is still writing
+	genMu sync.Mutex
+}
+
+// NewRegenerator returns a Regenerator writing to outPath (or opts.OutPath).
+// A debounce <= 0 uses DefaultDebounce.
+func NewRegenerator(app core.App, outPath string, debounce time.Duration, opts ...Options) *Regenerator {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	return &Regenerator{
+		app:      app,
+		outPath:  outPath,
+		debounce: debounce,
+		opts:     o,
+	}
+}
+
+// Bind registers the collection change hooks on the app and stops pending
+// generations when the app terminates.
+func (r *Regenerator) Bind() {
+	trigger := func(e *core.CollectionEvent) error {
+		r.Trigger()
+		return e.Next()
+	}
+
+	r.app.OnCollectionAfterCreateSuccess().BindFunc(trigger)
+	r.app.OnCollectionAfterUpdateSuccess().BindFunc(trigger)
+	r.app.OnCollectionAfterDeleteSuccess().BindFunc(trigger)
+
+	r.app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		r.Stop()
+		return e.Next()
+	})
+}
+
+// Trigger schedules a generation after the debounce duration,
+// postponing an already scheduled one.
+func (r *Regenerator) Trigger() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.timer = time.AfterFunc(r.debounce, r.run)
+}
+
+// Stop cancels a scheduled generation. A generation that already started is not interrupted.
+func (r *Regenerator) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+func (r *Regenerator) run() {
+	r.genMu.Lock()
+	defer r.genMu.Unlock()
+
+	start := time.Now()
+	logger := r.app.Logger()
+
+	res, err := GenerateTypesWithResult(r.app, r.outPath, r.opts)
+	if err != nil {
+		logger.Error("valibase: failed to regenerate types", "error", err)
+		return
+	}
+
+	logger.Info("valibase: regenerated types", "status", res.Status, "duration", time.Since(start).String())
+	if res.DocsStatus != "" {
+		logger.Info("valibase: regenerated docs", "status", res.DocsStatus)
+	}
+
+	if res.Changelog != nil && len(res.Changelog.Changes) > 0 {
+		if res.Changelog.HasBreaking() {
+			logger.Warn("valibase: breaking schema changes", "changes", res.Changelog.String())
+		} else {
+			logger.Info("valibase: schema changes", "changes", res.Changelog.String())
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Status reports what happened to the output file.
+type Status string
+
+const (
+	// Written means the output file was created or replaced.
+	Written Status = "written"
+	// Unchanged means the output file already had the generated content and was not touched.
+	Unchanged Status = "unchanged"
+)
+
+// writeFile replaces path with data, unless it already has the same content.
+//
+// The data is written to a temp file in the same directory which is then renamed
+// over path, so readers never see a partially written file. Skipping identical
+// content keeps the mtime, which avoids needless rebuilds of file watching dev servers.
+// A replaced file keeps its permissions, new files are created with 0644.
+func writeFile(path string, data []byte) (Status, error) {
+	mode := fs.FileMode(0o644)
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if sha256.Sum256(existing) == sha256.Sum256(data) {
+			return Unchanged, nil
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		mode = info.Mode().Perm()
+	case !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return Written, nil
+}
@@ -0,0 +1,2 @@
+// Package docs renders the collection model as a Markdown data dictionary
+package docs
@@ -0,0 +1,170 @@
+package docs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zenaxo/valibase/internal/utils"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// Markdown renders collections as a Markdown reference, with a section per
+// collection listing its access rules and a table of its fields.
+type Markdown struct{}
+
+func (Markdown) Emit(collections []schema.Collection) ([]byte, error) {
+	byID := make(map[string]schema.Collection, len(collections))
+	for _, c := range collections {
+		byID[c.ID] = c
+	}
+
+	var b strings.Builder
+	b.WriteString("# Collections\n\n")
+	for _, c := range collections {
+		fmt.Fprintf(&b, "- [%s](#%s) (%s)\n", c.Name, anchor(c.Name), c.Type)
+	}
+
+	for _, c := range collections {
+		writeCollection(&b, c, byID)
+	}
+
+	return []byte(b.String()), nil
+}
+
+func writeCollection(b *strings.Builder, c schema.Collection, byID map[string]schema.Collection) {
+	fmt.Fprintf(b, "\n## %s\n\n", c.Name)
+
+	kind := string(c.Type) + " collection"
+	if c.System {
+		kind = "System " + kind
+	} else {
+		kind = strings.ToUpper(kind[:1]) + kind[1:]
+	}
+	b.WriteString(kind + ".\n")
+
+	b.WriteString("\n| Operation | Access |\n|---|---|\n")
+	for _, r := range c.Rules() {
+		fmt.Fprintf(b, "| %s | %s |\n", r.Operation, ruleDescription(r))
+	}
+
+	b.WriteString("\n| Field | Type | Required | Unique | Constraints |\n|---|---|---|---|---|\n")
+	for _, f := range c.Fields {
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %s |\n",
+			code(f.Name),
+			f.Type,
+			check(f.Required),
+			check(f.Unique),
+			strings.Join(constraints(f, byID), "<br>"),
+		)
+	}
+}
+
+func ruleDescription(r schema.Rule) string {
+	switch r.Access() {
+	case schema.AccessSuperuser:
+		return "Superusers only"
+	case schema.AccessPublic:
+		return "Public"
+	default:
+		return code(strings.Join(strings.Fields(*r.Expr), " "))
+	}
+}
+
+// constraints describes the validation options of f, one entry per option.
+func constraints(f schema.Field, byID map[string]schema.Collection) []string {
+	var out []string
+	add := func(format string, args ...any) {
+		out = append(out, fmt.Sprintf(format, args...))
+	}
+
+	if f.Hidden {
+		add("Hidden")
+	}
+	if f.System {
+		add("System")
+	}
+
+	switch f.Type {
+	case schema.FieldNumber:
+		if f.MinValue != nil {
+			add("Min: %s", formatFloat(*f.MinValue))
+		}
+		if f.MaxValue != nil {
+			add("Max: %s", formatFloat(*f.MaxValue))
+		}
+		if f.NoDecimals {
+			add("Integers only")
+		}
+	case schema.FieldSelect, schema.FieldFile, schema.FieldRelation:
+		if f.Multiple() {
+			add("Max select: %d", *f.MaxSelect)
+		}
+	default:
+		if f.Min != nil && *f.Min > 0 {
+			add("Min length: %d", *f.Min)
+		}
+		if f.Max != nil && *f.Max > 0 {
+			add("Max length: %d", *f.Max)
+		}
+	}
+
+	if f.Pattern != nil && *f.Pattern != "" {
+		add("Pattern: %s", code(*f.Pattern))
+	}
+	if len(f.Values) > 0 {
+		values := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			values[i] = code(v)
+		}
+		add("Values: %s", strings.Join(values, ", "))
+	}
+	if f.MimeTypes != nil && len(*f.MimeTypes) > 0 {
+		add("Types: %s", strings.Join(*f.MimeTypes, ", "))
+	}
+	if f.MaxSize != nil && *f.MaxSize > 0 {
+		_, label := utils.SizeExpression(*f.MaxSize)
+		add("Max size: %s", label)
+	}
+	if f.OnlyDomains != nil && len(*f.OnlyDomains) > 0 {
+		add("Only domains: %s", strings.Join(*f.OnlyDomains, ", "))
+	}
+	if f.ExceptDomains != nil && len(*f.ExceptDomains) > 0 {
+		add("Except domains: %s", strings.Join(*f.ExceptDomains, ", "))
+	}
+
+	if f.Type == schema.FieldRelation && f.RelationCollectionID != nil {
+		if target, ok := byID[*f.RelationCollectionID]; ok {
+			add("Relation: [%s](#%s)", target.Name, anchor(target.Name))
+		} else {
+			add("Relation: %s", code(*f.RelationCollectionID))
+		}
+	}
+
+	return out
+}
+
+func check(b bool) string {
+	if b {
+		return "✓"
+	}
+	return ""
+}
+
+func formatFloat(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// code returns s as inline code, with pipes escaped so they don't split the table cell.
+func code(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+// anchor returns the GitHub heading anchor of a collection section.
+func anchor(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,81 @@
+package erd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenaxo/valibase/schema"
+)
+
+// DBML renders collections as DBML, e.g. for dbdiagram.io.
+//
+// Each collection becomes a table, single relations are many-to-one
+// references and multiple relations many-to-many references:
+//
+//	Ref: posts.author > users.id
+//	Ref: posts.tags <> tags.id
+type DBML struct{}
+
+func (DBML) Emit(collections []schema.Collection) ([]byte, error) {
+	var b strings.Builder
+
+	for i, c := range collections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "Table %s {\n", c.Name)
+		for _, f := range c.Fields {
+			b.WriteString("\t" + f.Name + " " + dbmlType(f))
+			if settings := dbmlSettings(f); len(settings) > 0 {
+				b.WriteString(" [" + strings.Join(settings, ", ") + "]")
+			}
+			b.WriteString("\n")
+		}
+		if c.Type != schema.CollectionBase {
+			fmt.Fprintf(&b, "\n\tNote: '%s collection'\n", c.Type)
+		}
+		b.WriteString("}\n")
+	}
+
+	rels := relations(collections)
+	if len(rels) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range rels {
+		op := ">"
+		if r.multiple {
+			op = "<>"
+		}
+		fmt.Fprintf(&b, "Ref: %s.%s %s %s.id\n", r.from.Name, r.field.Name, op, r.to.Name)
+	}
+
+	return []byte(b.String()), nil
+}
+
+// dbmlType quotes array types, DBML only accepts plain words unquoted.
+func dbmlType(f schema.Field) string {
+	t := columnType(f)
+	if strings.HasSuffix(t, "[]") {
+		return `"` + t + `"`
+	}
+	return t
+}
+
+func dbmlSettings(f schema.Field) []string {
+	var settings []string
+	if f.Name == "id" {
+		settings = append(settings, "pk")
+	} else {
+		if f.Unique {
+			settings = append(settings, "unique")
+		}
+		if f.Required {
+			settings = append(settings, "not null")
+		}
+	}
+	if f.Hidden {
+		settings = append(settings, "note: 'hidden'")
+	}
+	return settings
+}
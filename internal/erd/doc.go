@@ -0,0 +1,2 @@
+// Package erd renders the collection model as entity-relationship diagrams (Mermaid and DBML)
+package erd
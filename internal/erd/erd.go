@@ -0,0 +1,43 @@
+package erd
+
+import "github.com/zenaxo/valibase/schema"
+
+// relation is a relation field between two collections.
+type relation struct {
+	from     schema.Collection
+	field    schema.Field
+	to       schema.Collection
+	multiple bool
+}
+
+// relations returns the relation fields of collections whose target is part of collections.
+func relations(collections []schema.Collection) []relation {
+	byID := make(map[string]schema.Collection, len(collections))
+	for _, c := range collections {
+		byID[c.ID] = c
+	}
+
+	var out []relation
+	for _, c := range collections {
+		for _, f := range c.Fields {
+			if f.Type != schema.FieldRelation || f.RelationCollectionID == nil {
+				continue
+			}
+			target, ok := byID[*f.RelationCollectionID]
+			if !ok {
+				continue
+			}
+			out = append(out, relation{from: c, field: f, to: target, multiple: f.Multiple()})
+		}
+	}
+
+	return out
+}
+
+// columnType returns the type of f as shown in the diagrams, e.g. relation[] for multiple relations.
+func columnType(f schema.Field) string {
+	if f.Multiple() {
+		return string(f.Type) + "[]"
+	}
+	return string(f.Type)
+}
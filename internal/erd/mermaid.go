@@ -0,0 +1,88 @@
+package erd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenaxo/valibase/schema"
+)
+
+// Mermaid renders collections as a Mermaid erDiagram.
+//
+// Fields are listed with their type, id as PK, relations as FK, unique fields as UK
+// and the required flag as comment. Relations are drawn with their cardinality:
+//
+//	posts }o--|| users : "author"
+type Mermaid struct{}
+
+func (Mermaid) Emit(collections []schema.Collection) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+
+	for _, c := range collections {
+		fmt.Fprintf(&b, "\t%s {\n", c.Name)
+		for _, f := range c.Fields {
+			b.WriteString("\t\t" + columnType(f) + " " + f.Name)
+
+			if keys := mermaidKeys(f); len(keys) > 0 {
+				b.WriteString(" " + strings.Join(keys, ", "))
+			}
+			if comments := mermaidComments(f); len(comments) > 0 {
+				fmt.Fprintf(&b, " %q", strings.Join(comments, ", "))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\t}\n")
+	}
+
+	rels := relations(collections)
+	if len(rels) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range rels {
+		fmt.Fprintf(&b, "\t%s %s %s : %q\n", r.from.Name, mermaidCardinality(r), r.to.Name, r.field.Name)
+	}
+
+	return []byte(b.String()), nil
+}
+
+func mermaidKeys(f schema.Field) []string {
+	var keys []string
+	if f.Name == "id" {
+		keys = append(keys, "PK")
+	}
+	if f.Type == schema.FieldRelation {
+		keys = append(keys, "FK")
+	}
+	if f.Unique && f.Name != "id" {
+		keys = append(keys, "UK")
+	}
+	return keys
+}
+
+func mermaidComments(f schema.Field) []string {
+	var comments []string
+	if f.Required && f.Name != "id" {
+		comments = append(comments, "required")
+	}
+	if f.Hidden {
+		comments = append(comments, "hidden")
+	}
+	return comments
+}
+
+// mermaidCardinality returns the relationship of r, read from the collection holding the field:
+// any number of records reference one target (or several for multiple relations),
+// required relations reference at least one.
+func mermaidCardinality(r relation) string {
+	right := "|o"
+	switch {
+	case r.multiple && r.field.Required:
+		right = "|{"
+	case r.multiple:
+		right = "o{"
+	case r.field.Required:
+		right = "||"
+	}
+	return "}o--" + right
+}
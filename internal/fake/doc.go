@@ -0,0 +1,2 @@
+// Package fake generates values satisfying the constraints of collection fields
+package fake
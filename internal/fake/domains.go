@@ -0,0 +1,33 @@
+package fake
+
+import "strings"
+
+// Domains returns the hosts to generate URLs and emails for: only if set,
+// otherwise example domains not matching except.
+func Domains(only, except []string) []string {
+	if len(only) > 0 {
+		return only
+	}
+
+	var domains []string
+	for _, d := range []string{"example.com", "example.org", "example.net"} {
+		if !matchesDomain(d, except) {
+			domains = append(domains, d)
+		}
+	}
+	if len(domains) == 0 {
+		domains = append(domains, "valibase.test")
+	}
+	return domains
+}
+
+// matchesDomain reports whether host is one of domains or a subdomain of one,
+// like the onlyDomains and exceptDomains checks of PocketBase.
+func matchesDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
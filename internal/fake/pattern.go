@@ -0,0 +1,146 @@
+package fake
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// maxAttempts bounds the retries of Pattern when a value misses the length limits.
+const maxAttempts = 200
+
+// Pattern returns a random string matching pattern (Go regexp syntax, as used by
+// PocketBase) with a length in [lo, hi], hi <= 0 meaning no limit.
+func Pattern(r *rand.Rand, pattern string, lo, hi int) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	re = re.Simplify()
+
+	check, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+
+	// unbounded repeats get up to span extra items, wide enough to reach lo
+	span := lo + 10
+	if hi > 0 && span > hi {
+		span = hi
+	}
+
+	upper := lo + 10
+	if hi > 0 {
+		upper = max(lo, hi)
+	}
+
+	for attempt := range maxAttempts {
+		var b strings.Builder
+		gen := patternGen{r: r, span: span, b: &b}
+		if attempt%2 == 1 {
+			// fill up to a length in the limits, random repeats rarely hit exact lengths
+			gen.target = lo + r.IntN(upper-lo+1)
+		}
+		gen.write(re)
+
+		s := b.String()
+		n := len([]rune(s))
+		if n >= lo && (hi <= 0 || n <= hi) && check.MatchString(s) {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("pattern %q: no match with a length between %d and %d found", pattern, lo, hi)
+}
+
+type patternGen struct {
+	r    *rand.Rand
+	span int
+	b    *strings.Builder
+
+	// target is the length unbounded repeats fill up to, random counts if 0
+	target int
+}
+
+func (g patternGen) write(re *syntax.Regexp) {
+	b := g.b
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, c := range re.Rune {
+			b.WriteRune(c)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteRune(rune('a' + g.r.IntN(26)))
+	case syntax.OpCapture:
+		g.write(re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.write(sub)
+		}
+	case syntax.OpAlternate:
+		g.write(re.Sub[g.r.IntN(len(re.Sub))])
+	case syntax.OpStar:
+		g.repeat(re.Sub[0], 0, -1)
+	case syntax.OpPlus:
+		g.repeat(re.Sub[0], 1, -1)
+	case syntax.OpQuest:
+		g.repeat(re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		g.repeat(re.Sub[0], re.Min, re.Max)
+	}
+	// anchors, word boundaries and empty matches don't produce text
+}
+
+func (g patternGen) repeat(re *syntax.Regexp, min, max int) {
+	if max < 0 && g.target > 0 {
+		for range min {
+			g.write(re)
+		}
+		for n := min; g.b.Len() < g.target && n < min+g.target; n++ {
+			g.write(re)
+		}
+		return
+	}
+
+	if max < 0 {
+		max = min + g.span
+	}
+	for range min + g.r.IntN(max-min+1) {
+		g.write(re)
+	}
+}
+
+// classRune picks a rune of the class given as [lo, hi] pairs, preferring
+// letters and digits so the values stay readable.
+func (g patternGen) classRune(ranges []rune) rune {
+	var readable, all []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if hi > unicode.MaxASCII {
+			hi = max(lo, unicode.MaxASCII)
+		}
+		for c := lo; c <= hi && len(all) < 512; c++ {
+			if c > unicode.MaxASCII || unicode.IsPrint(c) {
+				all = append(all, c)
+			}
+			if unicode.IsLetter(c) || unicode.IsDigit(c) {
+				readable = append(readable, c)
+			}
+		}
+	}
+
+	switch {
+	case len(readable) > 0 && g.r.IntN(4) > 0:
+		return readable[g.r.IntN(len(readable))]
+	case len(all) > 0:
+		return all[g.r.IntN(len(all))]
+	case len(ranges) > 0:
+		return ranges[0]
+	}
+	return 'a'
+}
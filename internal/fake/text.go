@@ -0,0 +1,19 @@
+package fake
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// Text returns a random lowercase alphanumeric string with a length in [lo, hi].
+func Text(r *rand.Rand, lo, hi int) string {
+	hi = max(lo, hi)
+
+	var b strings.Builder
+	for range lo + r.IntN(hi-lo+1) {
+		b.WriteByte(alphabet[r.IntN(len(alphabet))])
+	}
+	return b.String()
+}
@@ -3,6 +3,9 @@ package gen
 import (
 	"fmt"
 	"strings"
+
+	"github.com/zenaxo/valibase/internal/valibot"
+	"github.com/zenaxo/valibase/schema"
 )
 
 var fieldsToIgnore = map[string]struct{}{
@@ -12,37 +15,157 @@ var fieldsToIgnore = map[string]struct{}{
 	"id":           {},
 }
 
-func shouldSkipField(f fieldSchema) bool {
+func shouldSkipField(c schema.Collection, f schema.Field) bool {
+	if c.Type == schema.CollectionView {
+		// views only have the columns they select, created/updated included
+		return f.Name == "id" || f.Hidden
+	}
 	if _, ok := fieldsToIgnore[f.Name]; ok {
 		return true
 	}
 	return f.Hidden
 }
 
+// Options configures GenerateTS.
+type Options struct {
+	// EmbedSchema prepends the collection model as a comment, see ReadSchemaHeader.
+	EmbedSchema bool
+
+	// Naming derives the identifiers of each collection, DefaultNaming if nil.
+	Naming Naming
+
+	// Names overrides the singular name of a collection, keyed by collection name.
+	Names map[string]string
+
+	// Messages overrides validation messages by rule key, see valibot.DefaultMessages.
+	Messages map[string]string
+
+	// MessageKeys emits msg(key, params) lookups instead of literal messages.
+	MessageKeys bool
+
+	// Fields overrides the schemas of fields, keyed by "collection.field".
+	Fields map[string]FieldOverride
+
+	// Checks are cross-field validations keyed by collection name.
+	Checks map[string][]Check
+
+	// Templates replaces the built-in imports, helpers and tail templates.
+	Templates Templates
+
+	// RuleAware omits the create, update and delete methods of operations
+	// restricted to superusers from TypedPocketBase and TypedBatch.
+	RuleAware bool
+
+	// Admin emits superuser schemas including the hidden fields, and the
+	// AdminResponseTypes and TypedAdminPocketBase types.
+	Admin bool
+}
+
+// tsGen holds the state shared by the writers of a single GenerateTS run.
+type tsGen struct {
+	w     *tsw
+	opts  Options
+	v     fieldWriter
+	names map[string]collNameParts
+	byID  map[string]schema.Collection
+}
+
+// Emitter is the default schema.Emitter, it renders the output of GenerateTS.
+type Emitter struct {
+	Options
+}
+
+func (e Emitter) Emit(collections []schema.Collection) ([]byte, error) {
+	ts, err := GenerateTS(collections, e.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.EmbedSchema {
+		header, err := schemaHeader(collections)
+		if err != nil {
+			return nil, fmt.Errorf("embed schema: %w", err)
+		}
+		ts = header + ts
+	}
+
+	return []byte(ts), nil
+}
+
 // GenerateTS generates the full TypeScript output for the provided collections.
-func GenerateTS(collections []collectionRecord) string {
-	w := &tsw{}
+// It fails if two collections resolve to the same identifier.
+func GenerateTS(collections []schema.Collection, opts Options) (string, error) {
+	names, err := resolveNames(collections, opts.Naming, opts.Names)
+	if err != nil {
+		return "", fmt.Errorf("names: %w", err)
+	}
+
+	if err := validateOverrides(collections, opts.Fields, opts.Checks, opts.Admin); err != nil {
+		return "", fmt.Errorf("overrides: %w", err)
+	}
+
+	messages, err := valibot.MergeMessages(opts.Messages, checkMessages(opts.Checks))
+	if err != nil {
+		return "", fmt.Errorf("messages: %w", err)
+	}
+
+	g := &tsGen{
+		w:     &tsw{},
+		opts:  opts,
+		v:     fieldWriter{Builder: valibot.New(messages, opts.MessageKeys)},
+		names: names,
+		byID:  make(map[string]schema.Collection, len(collections)),
+	}
+	w := g.w
 
 	collectionNames := make([]string, 0, len(collections))
-	byID := make(map[string]collectionRecord, len(collections))
+	authNames := make([]string, 0, len(collections))
+	viewNames := make([]string, 0, len(collections))
 
 	for _, c := range collections {
 		collectionNames = append(collectionNames, c.Name)
-		byID[c.ID] = c
+		switch c.Type {
+		case schema.CollectionAuth:
+			authNames = append(authNames, c.Name)
+		case schema.CollectionView:
+			viewNames = append(viewNames, c.Name)
+		}
+		g.byID[c.ID] = c
 	}
 
-	w.W(imports())
-	writeCollectionsSegment(w, collectionNames)
-	w.W(typeHelpers())
+	data := g.templateData(collections)
+
+	imports, err := g.renderTemplate(importsTemplate, opts.Templates.Imports, data)
+	if err != nil {
+		return "", fmt.Errorf("imports template: %w", err)
+	}
+	helpers, err := g.renderTemplate(helpersTemplate, opts.Templates.Helpers, data)
+	if err != nil {
+		return "", fmt.Errorf("helpers template: %w", err)
+	}
+	tail, err := g.renderTemplate(tailTemplate, opts.Templates.Tail, data)
+	if err != nil {
+		return "", fmt.Errorf("tail template: %w", err)
+	}
+
+	w.W(imports)
+	g.writeCollectionsSegment(collectionNames)
+	w.W(helpers)
 
 	for _, c := range collections {
-		writeCollectionSection(w, c, byID)
+		g.writeCollectionSection(c)
 	}
 
-	writeRegistry(w, collectionNames)
-	w.W(tail())
+	g.writeRegistry(collectionNames, viewNames)
+	g.writeAuthRegistry(authNames)
+	g.writeRules(collections)
+	w.W(tail)
+
+	if opts.Admin {
+		g.writeAdminRegistry(collectionNames)
+	}
 
-	return w.String()
+	return w.String(), nil
 }
 
 func sectionComment(w *tsw, name string) {
@@ -0,0 +1,109 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenaxo/valibase/schema"
+)
+
+// adminOnlyField reports whether f is only part of the superuser schemas.
+// System hidden fields (password, tokenKey) are never returned by the API.
+func adminOnlyField(f schema.Field) bool {
+	if _, ok := fieldsToIgnore[f.Name]; ok {
+		return false
+	}
+	return f.Hidden && !f.System
+}
+
+// writeAdminExports writes the superuser variant of the response and input
+// schemas, extending the regular ones with the hidden fields.
+func (g *tsGen) writeAdminExports(c schema.Collection, n collNameParts, hasExpand bool) {
+	isView := c.Type == schema.CollectionView
+
+	viewFields := make([]string, 0, len(c.Fields))
+	inputFields := make([]string, 0, len(c.Fields))
+
+	for _, f := range c.Fields {
+		if !adminOnlyField(f) {
+			continue
+		}
+
+		view, input := g.emitField(c, g.fieldWithOverride(c, f))
+		viewFields = append(viewFields, view)
+		inputFields = append(inputFields, input)
+	}
+
+	response := n.lowerCamelSingular + "Response"
+	input := n.lowerCamelSingular + "Input"
+	if len(viewFields) > 0 {
+		response = g.v.Object(fmt.Sprintf("{\n\t...%s.entries,\n\t%s\n}", response, strings.Join(viewFields, ",\n\t")))
+		input = g.v.Object(fmt.Sprintf("{\n\t...%s.entries,\n\t%s\n}", input, strings.Join(inputFields, ",\n\t")))
+	}
+
+	adminType := fmt.Sprintf("v.InferOutput<typeof %sAdminResponse>", n.lowerCamelSingular)
+	if hasExpand {
+		adminType += fmt.Sprintf(" & Expand<Partial<%sExpand>>", n.pascalSingular)
+	}
+
+	var b strings.Builder
+	b.WriteString("\n/**\n")
+	fmt.Fprintf(&b, "* Superuser schemas for %q, including hidden fields\n", n.collectionName)
+	b.WriteString("*/\n")
+	fmt.Fprintf(&b, "export const %sAdminResponse = %s;\n", n.lowerCamelSingular, response)
+	// views are read-only, there is nothing to input
+	if !isView {
+		fmt.Fprintf(&b, "export const %sAdminInput = %s;\n", n.lowerCamelSingular, input)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "export type %sAdmin = %s;\n", n.pascalSingular, adminType)
+	if !isView {
+		fmt.Fprintf(&b, "export type %[1]sAdminInput = v.InferOutput<typeof %[2]sAdminInput>;\n", n.pascalSingular, n.lowerCamelSingular)
+	}
+
+	g.w.W(b.String())
+}
+
+// writeAdminRegistry writes the record types and client type of superuser clients.
+func (g *tsGen) writeAdminRegistry(collectionNames []string) {
+	w := g.w
+	w.WL("")
+	w.WL("// Helper type map for superuser clients: collection name -> record including hidden fields")
+	w.WL("export type AdminResponseTypes = {")
+	w.Indent()
+
+	for _, coll := range collectionNames {
+		w.WL(fmt.Sprintf("%s: %sAdmin;", coll, g.names[coll].pascalSingular))
+	}
+
+	w.Dedent()
+	w.WL("};")
+
+	w.W(`
+// RecordService of superuser clients, records include hidden fields
+export type TypedAdminRecordService<N extends CollectionNameKey> = Omit<
+	RecordService<AdminResponseTypes[N]>,
+	'subscribe'
+> & {
+	subscribe(
+		topic: string,
+		callback: (e: { action: 'create' | 'update' | 'delete'; record: AdminResponseTypes[N] }) => void,
+		options?: RecordSubscribeOptions
+	): Promise<UnsubscribeFunc>;
+};
+
+/**
+ * PocketBase client authenticated as a superuser, records include hidden fields
+ *
+ * 		const pb = new PocketBase(PUBLIC_PB) as TypedAdminPocketBase
+ * 		await pb.collection('_superusers').authWithPassword(email, password)
+ */
+export type TypedAdminPocketBase = {
+	collection<T extends CollectionNameKey>(
+		idOrName: T
+	): T extends ViewCollectionNameKey
+		? Omit<TypedAdminRecordService<T>, 'create' | 'update' | 'delete'>
+		: TypedAdminRecordService<T>;
+} & PocketBase;
+`)
+}
@@ -0,0 +1,32 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/zenaxo/valibase/internal/utils"
+	"github.com/zenaxo/valibase/schema"
+)
+
+func (g *tsGen) writeAuthExports(c schema.Collection, n collNameParts) {
+	g.w.W(fmt.Sprintf(`
+// OAuth2 providers enabled for %q
+export type %sOAuth2Providers = OAuth2Providers<[%s]>;
+`, n.collectionName, n.pascalSingular, utils.ToQuotedStringArray(c.OAuth2Providers)))
+}
+
+func (g *tsGen) writeAuthRegistry(authNames []string) {
+	w := g.w
+	w.WL("")
+	w.WL("// Helper type map: auth collection name -> enabled OAuth2 providers")
+	w.WL("export type AuthProviders = {")
+	w.Indent()
+
+	for _, coll := range authNames {
+		w.WL(fmt.Sprintf("%s: %sOAuth2Providers;", coll, g.names[coll].pascalSingular))
+	}
+
+	w.Dedent()
+	w.WL("};")
+	w.WL("")
+	w.WL("export type AuthCollectionNameKey = keyof AuthProviders & CollectionNameKey;")
+}
@@ -4,16 +4,17 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/zenaxo/valibase/internal/utils"
+	"github.com/zenaxo/valibase/schema"
 )
 
-func writeCollectionsSegment(w *tsw, colls []string) {
+func (g *tsGen) writeCollectionsSegment(colls []string) {
+	w := g.w
 	w.WL("")
 	w.WL("// All available PocketBase collections as a const map")
 	w.WL("export const Collections = {")
 	w.Indent()
 	for _, c := range colls {
-		w.WL(fmt.Sprintf("%s: '%s',", utils.ToPascalCase(c), c))
+		w.WL(fmt.Sprintf("%s: '%s',", g.names[c].key, c))
 	}
 	w.Dedent()
 	w.WL("} as const;")
@@ -21,43 +22,87 @@ func writeCollectionsSegment(w *tsw, colls []string) {
 	w.WL("export type CollectionName = (typeof Collections)[CollectionKey];")
 }
 
-func writeCollectionSection(w *tsw, c collectionRecord, byID map[string]collectionRecord) {
-	n := nameParts(c.Name)
+func (g *tsGen) writeCollectionSection(c schema.Collection) {
+	w := g.w
+	n := g.names[c.Name]
 	sectionComment(w, n.collectionName)
 
 	// Gather field snippets
 	viewFields := make([]string, 0, len(c.Fields))
 	inputFields := make([]string, 0, len(c.Fields))
 	expandFields := make([]string, 0, len(c.Fields))
+	metaFields := make([]string, 0, len(c.Fields))
+	emptyFields := make([]string, 0, len(c.Fields))
 
 	for _, f := range c.Fields {
-		if shouldSkipField(f) {
+		if shouldSkipField(c, f) {
 			continue
 		}
+		f = g.fieldWithOverride(c, f)
 
-		view, input := emitField(f)
+		view, input := g.emitField(c, f)
 		viewFields = append(viewFields, view)
 		inputFields = append(inputFields, input)
+		if meta, ok := formMetaField(f, g.byID); ok {
+			metaFields = append(metaFields, meta)
+		}
+		emptyFields = append(emptyFields, sanitizeFieldName(f.Name)+": "+emptyValue(f, "Create"+n.pascalSingular+"Input"))
 
-		if expandField, ok := expandFieldSnippet(f, byID); ok {
+		if expandField, ok := g.expandFieldSnippet(f); ok {
 			expandFields = append(expandFields, expandField)
 		}
 	}
 
-	w.W(collectionFieldsSchema(n.collectionName, strings.Join(viewFields, ",\n\t")))
-	w.W(collectionInputSchema(n.collectionName, strings.Join(inputFields, ",\n\t")))
+	w.W(g.collectionFieldsSchema(c, n, strings.Join(viewFields, ",\n\t")))
+	if c.Type != schema.CollectionView {
+		w.W(g.collectionInputSchema(n, strings.Join(inputFields, ",\n\t")))
+	}
 
 	w.W(fmt.Sprintf(`
 export type %sFields = v.InferOutput<typeof %sResponse>;
 `, n.pascalSingular, n.lowerCamelSingular))
 
-	writeExportType(w, n.collectionName, n.pascalSingular, expandFields)
+	g.writeExportType(c, n, expandFields)
+
+	// views are read-only, they only get the response schema and types
+	if c.Type == schema.CollectionView {
+		if g.opts.Admin {
+			g.writeAdminExports(c, n, len(expandFields) > 0)
+		}
+		return
+	}
+
+	w.W(createUpdateExports(n, c.Type, g.collectionChecks(c.Name)))
+	w.W(collectionFormMeta(n, strings.Join(metaFields, ",\n\t")))
+	w.W(collectionEmptyInput(n, c.Type, emptyFields))
+
+	if c.Type == schema.CollectionAuth {
+		g.writeAuthExports(c, n)
+	}
+
+	if g.opts.Admin {
+		g.writeAdminExports(c, n, len(expandFields) > 0)
+	}
+}
+
+// emitField returns the response and input entries of f, with its override applied.
+func (g *tsGen) emitField(c schema.Collection, f schema.Field) (view, input string) {
+	prefix := fmt.Sprintf("%s: ", sanitizeFieldName(f.Name))
+	w := g.v
+	if o := g.opts.Fields[c.Name+"."+f.Name]; o.Input == "" {
+		w.actions = o.Append
+	}
 
-	w.W(createUpdateExports(n.collectionName, c.Type))
+	viewSchema, inputSchema := w.fieldSchemas(f)
+	viewSchema, inputSchema = g.applyOverride(c, f, viewSchema, inputSchema)
+	return prefix + viewSchema, prefix + inputSchema
 }
 
-func collectionFieldsSchema(collectionName, content string) string {
-	n := nameParts(collectionName)
+func (g *tsGen) collectionFieldsSchema(c schema.Collection, n collNameParts, content string) string {
+	systemFields := "systemFieldsSchema"
+	if c.Type == schema.CollectionView {
+		systemFields = "viewSystemFieldsSchema"
+	}
 
 	var b strings.Builder
 	b.WriteString("\n/**\n")
@@ -65,25 +110,23 @@ func collectionFieldsSchema(collectionName, content string) string {
 	b.WriteString("*/\n")
 	fmt.Fprintf(&b, "export const %sResponse = %s;\n",
 		n.lowerCamelSingular,
-		v.Object(fmt.Sprintf(`{
-	...systemFieldsSchema('%s').entries,
+		g.v.Object(fmt.Sprintf(`{
+	...%s('%s').entries,
 	%s
-}`, n.collectionName, content)),
+}`, systemFields, n.collectionName, content)),
 	)
 
 	return b.String()
 }
 
-func collectionInputSchema(collectionName, content string) string {
-	n := nameParts(collectionName)
-
+func (g *tsGen) collectionInputSchema(n collNameParts, content string) string {
 	var b strings.Builder
 	b.WriteString("\n/*\n")
 	fmt.Fprintf(&b, "* Input schema for creating/updating %q\n", n.collectionName)
 	b.WriteString("*/\n")
 	fmt.Fprintf(&b, "export const %sInput = %s;\n",
 		n.lowerCamelSingular,
-		v.Object(fmt.Sprintf(`{
+		g.v.Object(fmt.Sprintf(`{
 	%s
 }`, content)),
 	)
@@ -91,9 +134,7 @@ func collectionInputSchema(collectionName, content string) string {
 	return b.String()
 }
 
-func collectionExpandType(collectionName, content string) string {
-	n := nameParts(collectionName)
-
+func collectionExpandType(n collNameParts, content string) string {
 	trimmed := strings.TrimSpace(content)
 	if trimmed == "" {
 		return fmt.Sprintf("\n// No expand relations defined for %s\nexport type %sExpand = {};\n",
@@ -113,41 +154,42 @@ func collectionExpandType(collectionName, content string) string {
 	return b.String()
 }
 
-func relationExpandTSType(f fieldSchema, byID map[string]collectionRecord) (string, bool) {
-	if f.Type != FieldRelation || f.RelationCollectionID == nil {
+func (g *tsGen) relationExpandTSType(f schema.Field) (string, bool) {
+	if f.Type != schema.FieldRelation || f.RelationCollectionID == nil {
 		return "", false
 	}
 
-	targetColl, ok := byID[*f.RelationCollectionID]
+	targetColl, ok := g.byID[*f.RelationCollectionID]
 	if !ok {
 		return "", false
 	}
 
-	target := utils.ToPascalCase(utils.ToSingular(targetColl.Name))
+	target := g.names[targetColl.Name].pascalSingular
 	if f.MaxSelect != nil && *f.MaxSelect == 1 {
 		return target, true
 	}
 	return target + "[]", true
 }
 
-func expandFieldSnippet(f fieldSchema, byID map[string]collectionRecord) (string, bool) {
-	tsType, ok := relationExpandTSType(f, byID)
+func (g *tsGen) expandFieldSnippet(f schema.Field) (string, bool) {
+	tsType, ok := g.relationExpandTSType(f)
 	if !ok {
 		return "", false
 	}
 	return sanitizeFieldName(f.Name) + "?: " + tsType, true
 }
 
-func writeExportType(w *tsw, collectionName, pascalSingular string, expandFields []string) {
-	parts := []string{pascalSingular + "Fields"}
+func (g *tsGen) writeExportType(c schema.Collection, n collNameParts, expandFields []string) {
+	w := g.w
+	parts := []string{n.pascalSingular + "Fields"}
 
 	if len(expandFields) > 0 {
 		expandBody := strings.Join(expandFields, ";\n\t")
-		w.W(collectionExpandType(collectionName, expandBody))
-		parts = append(parts, `Expand<Partial<`+pascalSingular+`Expand>>`)
+		w.W(collectionExpandType(n, expandBody))
+		parts = append(parts, `Expand<Partial<`+n.pascalSingular+`Expand>>`)
 	}
 
 	w.W(fmt.Sprintf(`
-export type %s = %s;
-`, pascalSingular, strings.Join(parts, " & ")))
+%sexport type %s = %s;
+`, rulesDoc(c), n.pascalSingular, strings.Join(parts, " & ")))
 }
@@ -0,0 +1,71 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenaxo/valibase/schema"
+)
+
+// emptyValue returns the initial form value of f as a TypeScript expression.
+// The literals of branded schemas (e.g. emailSchema) are cast to the field type of inputType,
+// fields without an empty value (single selects and files) start as undefined of that type.
+func emptyValue(f schema.Field, inputType string) string {
+	isMany := f.MaxSelect == nil || *f.MaxSelect != 1
+	typed := func(value string) string {
+		return fmt.Sprintf("%s as NonNullable<%s['%s']>", value, inputType, f.Name)
+	}
+	unset := fmt.Sprintf("undefined as %s['%s'] | undefined", inputType, f.Name)
+
+	switch f.Type {
+	case schema.FieldBool:
+		return "false"
+	case schema.FieldNumber:
+		if f.MinValue != nil {
+			return fmt.Sprintf("%v", *f.MinValue)
+		}
+		return "0"
+	case schema.FieldGeoPoint:
+		return typed("{ lon: 0, lat: 0 }")
+	case schema.FieldSelect:
+		if isMany {
+			return typed("[]")
+		}
+		return unset
+	case schema.FieldRelation:
+		if isMany {
+			return typed("[]")
+		}
+		return typed("''")
+	case schema.FieldFile:
+		if isMany {
+			return typed("[]")
+		}
+		return unset
+	case schema.FieldText:
+		return "''"
+	case schema.FieldEditor, schema.FieldEmail, schema.FieldURL, schema.FieldDate, schema.FieldAutoDate, schema.FieldJSON:
+		return typed("''")
+	default:
+		return "undefined"
+	}
+}
+
+func collectionEmptyInput(n collNameParts, cType schema.CollectionType, content []string) string {
+	if cType == schema.CollectionAuth {
+		content = append(content, "password: ''", "passwordConfirm: ''")
+	}
+
+	var b strings.Builder
+	b.WriteString("\n/**\n")
+	fmt.Fprintf(&b, "* Initial form state for creating %q records\n", n.collectionName)
+	b.WriteString("*/\n")
+	fmt.Fprintf(&b, "export const empty%sInput = () =>\n\t({\n\t\t%s\n\t}) satisfies { [K in keyof Create%sInput]-?: Create%sInput[K] | undefined };\n",
+		n.pascalSingular,
+		strings.Join(content, ",\n\t\t"),
+		n.pascalSingular,
+		n.pascalSingular,
+	)
+
+	return b.String()
+}
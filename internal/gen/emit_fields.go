@@ -1,134 +1,154 @@
 package gen
 
 import (
-	"fmt"
+	"slices"
 
 	"github.com/zenaxo/valibase/internal/valibot"
+	"github.com/zenaxo/valibase/schema"
 )
 
-var v = valibot.V
+// fieldWriter writes the valibot schemas of fields.
+type fieldWriter struct {
+	valibot.Builder
 
-func emitField(f fieldSchema) (view, input string) {
-	prefix := fmt.Sprintf("%s: ", sanitizeFieldName(f.Name))
-	viewSchema, inputSchema := fieldSchemas(f)
-	return prefix + viewSchema, prefix + inputSchema
+	// actions are piped at the end of input schemas, inside the optional wrapper,
+	// see FieldOverride.Append.
+	actions []string
 }
 
-func fieldSchemas(f fieldSchema) (view, input string) {
+// input pipes the actions after schema.
+func (v fieldWriter) input(schema string) string {
+	if len(v.actions) == 0 {
+		return schema
+	}
+	return v.Pipe(slices.Concat([]string{schema}, v.actions)...)
+}
+
+// inputPipe returns the pipe of schemas, with the actions at its end.
+func (v fieldWriter) inputPipe(schemas ...string) string {
+	return v.Pipe(slices.Concat(schemas, v.actions)...)
+}
+
+func (v fieldWriter) fieldSchemas(f schema.Field) (view, input string) {
 	switch f.Type {
-	case FieldBool:
-		return boolField(f.Required)
-	case FieldAutoDate:
-		return autoDateFieldsSchemas(f.Required)
-	case FieldDate:
-		return dateFieldsSchemas(f.Required)
-	case FieldEditor:
-		return editorFieldsSchemas(f.Required)
-	case FieldEmail:
-		return emailFieldsSchema(f.Required)
-	case FieldFile:
-		return fileFieldSchemas(f)
-	case FieldGeoPoint:
-		return geoPointFieldSchemas(f.Required)
-	case FieldJSON:
-		return jsonFieldSchemas(f.Required)
-	case FieldNumber:
-		return numberFieldSchemas(f)
-	case FieldRelation:
-		return relationFieldSchemas(f)
-	case FieldSelect:
-		return selectFieldSchemas(f)
-	case FieldText:
-		return textFieldSchemas(f)
-	case FieldURL:
-		return urlFieldSchemas(f)
+	case schema.FieldBool:
+		return v.boolField(f.Required)
+	case schema.FieldAutoDate:
+		return v.autoDateFieldsSchemas(f.Required)
+	case schema.FieldDate:
+		return v.dateFieldsSchemas(f.Required)
+	case schema.FieldEditor:
+		return v.editorFieldsSchemas(f.Required)
+	case schema.FieldEmail:
+		return v.emailFieldsSchema(f.Required)
+	case schema.FieldFile:
+		return v.fileFieldSchemas(f)
+	case schema.FieldGeoPoint:
+		return v.geoPointFieldSchemas(f.Required)
+	case schema.FieldJSON:
+		return v.jsonFieldSchemas(f.Required)
+	case schema.FieldNumber:
+		return v.numberFieldSchemas(f)
+	case schema.FieldRelation:
+		return v.relationFieldSchemas(f)
+	case schema.FieldSelect:
+		return v.selectFieldSchemas(f)
+	case schema.FieldText:
+		return v.textFieldSchemas(f)
+	case schema.FieldURL:
+		return v.urlFieldSchemas(f)
 	default:
-		return "v.any()", "v.any()"
+		return "v.any()", v.input("v.any()")
 	}
 }
 
-func pbTextOptional(required bool, base string) (view, input string) {
+func (v fieldWriter) pbTextOptional(required bool, base string) (view, input string) {
+	input = v.input(base)
 	if required {
-		return base, base
+		return base, input
 	}
-	return v.OptionalTextResponse(base), v.Optional(base)
+	return v.OptionalTextResponse(base), v.Optional(input)
 }
 
-func pbOptionalArray(required bool, base string) string {
+func (v fieldWriter) pbOptionalArray(required bool, base string) string {
 	if required {
 		return base
 	}
 	return v.Optional(base, "[]")
 }
 
-func diffField(required bool, viewSchema, inputSchema string) (view, input string) {
+func (v fieldWriter) diffField(required bool, viewSchema, inputSchema string) (view, input string) {
 	if required {
 		return viewSchema, inputSchema
 	}
 	return v.Optional(viewSchema), v.Optional(inputSchema)
 }
 
-func jsonFieldSchemas(required bool) (view, input string) {
-	return pbTextOptional(required, v.JSON())
+func (v fieldWriter) jsonFieldSchemas(required bool) (view, input string) {
+	return v.pbTextOptional(required, v.JSON())
 }
 
-func editorFieldsSchemas(required bool) (view, input string) {
-	return pbTextOptional(required, v.Editor())
+func (v fieldWriter) editorFieldsSchemas(required bool) (view, input string) {
+	return v.pbTextOptional(required, v.Editor())
 }
 
-func dateFieldsSchemas(required bool) (view, input string) {
-	return pbTextOptional(required, v.IsoDate())
+func (v fieldWriter) dateFieldsSchemas(required bool) (view, input string) {
+	return v.pbTextOptional(required, v.IsoDate())
 }
 
-func autoDateFieldsSchemas(required bool) (view, input string) {
-	return pbTextOptional(required, v.IsoAutoDate())
+func (v fieldWriter) autoDateFieldsSchemas(required bool) (view, input string) {
+	return v.pbTextOptional(required, v.IsoAutoDate())
 }
 
-func boolField(required bool) (view, input string) {
+func (v fieldWriter) boolField(required bool) (view, input string) {
 	base := v.Boolean()
 	if required {
-		return base, v.Literal("true")
+		return base, v.input(v.Literal("true"))
 	}
-	return v.Optional(base), v.Optional(base)
+	return v.Optional(base), v.Optional(v.input(base))
 }
 
-func emailFieldsSchema(required bool) (view, input string) {
+func (v fieldWriter) emailFieldsSchema(required bool) (view, input string) {
 	base := v.EmailSchema()
 	view = v.OptionalTextResponse(base)
 	if required {
-		input = base
+		input = v.input(base)
 	} else {
-		input = v.Optional(base)
+		input = v.Optional(v.input(base))
 	}
 	return view, input
 }
 
-func geoPointFieldSchemas(required bool) (view, input string) {
+func (v fieldWriter) geoPointFieldSchemas(required bool) (view, input string) {
 	base := v.GeoPoint()
 	if required {
-		return base, base
+		return base, v.input(base)
 	}
-	return v.Optional(base), v.Optional(base)
+	return v.Optional(base), v.Optional(v.input(base))
 }
 
-func relationFieldSchemas(f fieldSchema) (view, input string) {
+func (v fieldWriter) relationFieldSchemas(f schema.Field) (view, input string) {
 	maxSel := f.MaxSelect
 	isMany := maxSel == nil || *maxSel != 1
 
 	if isMany {
-		base := v.Pipe(v.Array(v.String()), v.Brand("RelationMultiple"))
-		return pbOptionalArray(f.Required, base), pbOptionalArray(f.Required, base)
+		mods := []string{v.Array(v.String()), v.Brand("RelationMultiple")}
+		return v.pbOptionalArray(f.Required, v.Pipe(mods...)), v.pbOptionalArray(f.Required, v.inputPipe(mods...))
 	}
 
-	base := v.Pipe(
+	mods := []string{
 		v.String(),
 		v.Length(15),
 		v.Brand("Relation"),
-	)
-	return pbTextOptional(f.Required, base)
+	}
+	base, input := v.Pipe(mods...), v.inputPipe(mods...)
+	if f.Required {
+		return base, input
+	}
+	return v.OptionalTextResponse(base), v.Optional(input)
 }
 
-func fileFieldSchemas(f fieldSchema) (view, input string) {
+func (v fieldWriter) fileFieldSchemas(f schema.Field) (view, input string) {
 	maxSel := f.MaxSelect
 	isMany := maxSel == nil || *maxSel != 1
 
@@ -139,22 +159,20 @@ func fileFieldSchemas(f fieldSchema) (view, input string) {
 	if f.MaxSize != nil {
 		mods = append(mods, v.MaxSize(*f.MaxSize))
 	}
-	perFileInput := v.Pipe(mods...)
-
 	if isMany {
-		base := v.Array(perFileInput)
-		input = pbOptionalArray(f.Required, base)
+		base := v.Array(v.Pipe(mods...))
+		input = v.pbOptionalArray(f.Required, v.input(base))
 	} else {
 		if f.Required {
-			input = perFileInput
+			input = v.inputPipe(mods...)
 		} else {
-			input = v.Optional(perFileInput)
+			input = v.Optional(v.inputPipe(mods...))
 		}
 	}
 
 	if isMany {
 		base := v.Array(v.FileName)
-		view = pbOptionalArray(f.Required, base)
+		view = v.pbOptionalArray(f.Required, base)
 	} else {
 		if f.Required {
 			view = v.File
@@ -166,9 +184,9 @@ func fileFieldSchemas(f fieldSchema) (view, input string) {
 	return view, input
 }
 
-func urlFieldSchemas(f fieldSchema) (view, input string) {
+func (v fieldWriter) urlFieldSchemas(f schema.Field) (view, input string) {
 	if f.OnlyDomains != nil {
-		inputBase := v.OnlyDomains(*f.OnlyDomains)
+		inputBase := v.input(v.OnlyDomains(*f.OnlyDomains))
 		if f.Required {
 			return v.URLSchema(), inputBase
 		}
@@ -176,49 +194,49 @@ func urlFieldSchemas(f fieldSchema) (view, input string) {
 	}
 
 	if f.ExceptDomains != nil {
-		inputBase := v.ExceptDomains(*f.ExceptDomains)
+		inputBase := v.input(v.ExceptDomains(*f.ExceptDomains))
 		if f.Required {
 			return v.URLSchema(), inputBase
 		}
 		return v.OptionalTextResponse(v.URLSchema()), v.Optional(inputBase)
 	}
 
-	return pbTextOptional(f.Required, v.URLSchema())
+	return v.pbTextOptional(f.Required, v.URLSchema())
 }
 
-func selectFieldSchemas(f fieldSchema) (view, input string) {
+func (v fieldWriter) selectFieldSchemas(f schema.Field) (view, input string) {
 	viewBase := v.Array(v.String())
 	maxSel := f.MaxSelect
 	enum := v.StringEnum(f.Values)
 
 	if maxSel != nil && *maxSel == 1 {
-		return diffField(f.Required, viewBase, enum)
+		return v.diffField(f.Required, viewBase, v.input(enum))
 	}
 
 	baseInput := v.Array(enum)
 
 	if maxSel == nil && !f.Required {
-		return diffField(f.Required, viewBase, baseInput)
+		return v.diffField(f.Required, viewBase, v.input(baseInput))
 	}
 
 	switch {
 	case f.Required && maxSel != nil:
-		input = v.Pipe(baseInput, v.MinLength(1), v.MaxLength(*maxSel))
+		input = v.inputPipe(baseInput, v.MinLength(1), v.MaxLength(*maxSel))
 	case f.Required && maxSel == nil:
-		input = v.Pipe(baseInput, v.MinLength(1))
+		input = v.inputPipe(baseInput, v.MinLength(1))
 	case !f.Required && maxSel != nil:
-		input = v.Pipe(baseInput, v.MaxLength(*maxSel))
+		input = v.inputPipe(baseInput, v.MaxLength(*maxSel))
 	}
 
-	return diffField(f.Required, viewBase, input)
+	return v.diffField(f.Required, viewBase, input)
 }
 
-func textFieldSchemas(f fieldSchema) (view, input string) {
+func (v fieldWriter) textFieldSchemas(f schema.Field) (view, input string) {
 	required := f.Required
 	min, max, pattern := f.Min, f.Max, f.Pattern
 
 	if min == nil && max == nil && pattern == nil {
-		return pbTextOptional(required, v.String())
+		return v.pbTextOptional(required, v.String())
 	}
 
 	if required {
@@ -244,7 +262,7 @@ func textFieldSchemas(f fieldSchema) (view, input string) {
 		mods = append(mods, v.Pattern(*pattern))
 	}
 
-	input = v.Pipe(mods...)
+	input = v.inputPipe(mods...)
 	if !required {
 		input = v.Optional(input)
 	}
@@ -252,7 +270,7 @@ func textFieldSchemas(f fieldSchema) (view, input string) {
 	return view, input
 }
 
-func numberFieldSchemas(f fieldSchema) (view, input string) {
+func (v fieldWriter) numberFieldSchemas(f schema.Field) (view, input string) {
 	base := v.Number()
 	if f.Required {
 		view = base
@@ -282,7 +300,7 @@ func numberFieldSchemas(f fieldSchema) (view, input string) {
 	}
 
 	args := append([]string{v.Number()}, mods...)
-	inputBase := v.Pipe(args...)
+	inputBase := v.inputPipe(args...)
 
 	if f.Required {
 		return view, inputBase
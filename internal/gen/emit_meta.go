@@ -0,0 +1,102 @@
+package gen
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zenaxo/valibase/internal/utils"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// formMetaTypes are the field types of the FieldMeta type in the helpers.
+var formMetaTypes = []schema.FieldType{
+	schema.FieldText,
+	schema.FieldNumber,
+	schema.FieldBool,
+	schema.FieldEmail,
+	schema.FieldURL,
+	schema.FieldEditor,
+	schema.FieldDate,
+	schema.FieldAutoDate,
+	schema.FieldSelect,
+	schema.FieldJSON,
+	schema.FieldFile,
+	schema.FieldRelation,
+	schema.FieldGeoPoint,
+}
+
+// formMetaField returns the form metadata entry of f, e.g.
+//
+//	title: { type: 'text', required: true, min: 3, max: 120 }
+//
+// ok is false for field types FieldMeta doesn't know (e.g. from a newer PocketBase),
+// which get no entry.
+func formMetaField(f schema.Field, byID map[string]schema.Collection) (entry string, ok bool) {
+	if !slices.Contains(formMetaTypes, f.Type) {
+		return "", false
+	}
+
+	props := []string{
+		fmt.Sprintf("type: '%s'", f.Type),
+		fmt.Sprintf("required: %t", f.Required),
+	}
+
+	if f.Min != nil {
+		props = append(props, fmt.Sprintf("min: %d", *f.Min))
+	}
+	if f.Max != nil {
+		props = append(props, fmt.Sprintf("max: %d", *f.Max))
+	}
+	if f.MinValue != nil {
+		props = append(props, fmt.Sprintf("min: %v", *f.MinValue))
+	}
+	if f.MaxValue != nil {
+		props = append(props, fmt.Sprintf("max: %v", *f.MaxValue))
+	}
+	if f.NoDecimals {
+		props = append(props, "onlyInt: true")
+	}
+	if f.Pattern != nil {
+		props = append(props, "pattern: "+strconv.Quote(*f.Pattern))
+	}
+	if len(f.Values) > 0 {
+		props = append(props, "values: ["+utils.ToQuotedStringArray(f.Values)+"]")
+	}
+	if f.MaxSelect != nil {
+		props = append(props, fmt.Sprintf("maxSelect: %d", *f.MaxSelect))
+	}
+	if f.MimeTypes != nil {
+		props = append(props, "mimeTypes: ["+utils.ToQuotedStringArray(*f.MimeTypes)+"]")
+	}
+	if f.MaxSize != nil {
+		props = append(props, fmt.Sprintf("maxSize: %d", *f.MaxSize))
+	}
+	if f.OnlyDomains != nil {
+		props = append(props, "onlyDomains: ["+utils.ToQuotedStringArray(*f.OnlyDomains)+"]")
+	}
+	if f.ExceptDomains != nil {
+		props = append(props, "exceptDomains: ["+utils.ToQuotedStringArray(*f.ExceptDomains)+"]")
+	}
+	if f.RelationCollectionID != nil {
+		if target, ok := byID[*f.RelationCollectionID]; ok {
+			props = append(props, fmt.Sprintf("collection: '%s'", target.Name))
+		}
+	}
+
+	return sanitizeFieldName(f.Name) + ": { " + strings.Join(props, ", ") + " }", true
+}
+
+func collectionFormMeta(n collNameParts, content string) string {
+	var b strings.Builder
+	b.WriteString("\n/**\n")
+	fmt.Fprintf(&b, "* Form metadata for the %q inputs\n", n.collectionName)
+	b.WriteString("*/\n")
+	fmt.Fprintf(&b, "export const %sFormMeta = {\n\t%s\n} as const satisfies FormMeta;\n",
+		n.lowerCamelSingular,
+		content,
+	)
+
+	return b.String()
+}
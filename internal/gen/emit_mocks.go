@@ -0,0 +1,319 @@
+package gen
+
+import (
+	_ "embed"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/zenaxo/valibase/internal/fake"
+	"github.com/zenaxo/valibase/internal/valibot"
+	"github.com/zenaxo/valibase/schema"
+)
+
+//go:embed templates/mocks.ts.txt
+var mocksRaw string
+
+var mocksTemplate = template.Must(template.New("mocks").Parse(normalize(mocksRaw)))
+
+// patternSamples is the number of values generated for fields with a pattern,
+// the factories pick one of them at runtime.
+const patternSamples = 8
+
+// MockEmitter renders record factories for the types generated with the same Options.
+type MockEmitter struct {
+	Options
+
+	// Import is the module the generated types are imported from, e.g. "./database".
+	Import string
+}
+
+func (e MockEmitter) Emit(collections []schema.Collection) ([]byte, error) {
+	ts, err := GenerateMocks(collections, e.Options, e.Import)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(ts), nil
+}
+
+// GenerateMocks generates a make<Type>(overrides) factory per collection, building
+// records as returned by the API (the input of the <type>Response schema).
+//
+// Values are drawn from a seeded PRNG at runtime and respect the field constraints.
+// Patterns can't be evaluated by the runtime, so a few matching values are generated
+// here, from a seed derived from the field so the output stays stable.
+func GenerateMocks(collections []schema.Collection, opts Options, importPath string) (string, error) {
+	names, err := resolveNames(collections, opts.Naming, opts.Names)
+	if err != nil {
+		return "", fmt.Errorf("names: %w", err)
+	}
+
+	if err := validateOverrides(collections, opts.Fields, opts.Checks, opts.Admin); err != nil {
+		return "", fmt.Errorf("overrides: %w", err)
+	}
+
+	g := &tsGen{
+		w:     &tsw{},
+		opts:  opts,
+		names: names,
+	}
+	w := g.w
+
+	responses := make([]string, len(collections))
+	for i, c := range collections {
+		responses[i] = names[c.Name].lowerCamelSingular + "Response"
+	}
+
+	var head strings.Builder
+	err = mocksTemplate.Execute(&head, struct {
+		Responses []string
+		Import    string
+	}{responses, valibot.Quote(importPath)})
+	if err != nil {
+		return "", fmt.Errorf("mocks template: %w", err)
+	}
+	w.W(head.String())
+
+	for _, c := range collections {
+		if err := g.writeMockFactory(c); err != nil {
+			return "", err
+		}
+	}
+
+	return w.String(), nil
+}
+
+func (g *tsGen) writeMockFactory(c schema.Collection) error {
+	w := g.w
+	n := g.names[c.Name]
+	record := fmt.Sprintf("v.InferInput<typeof %sResponse>", n.lowerCamelSingular)
+
+	entries := []string{
+		"id: id()",
+		"collectionId: " + valibot.Quote(c.ID),
+		"collectionName: " + valibot.Quote(c.Name),
+	}
+	if c.Type != schema.CollectionView {
+		entries = append(entries, "created: date()", "updated: date()")
+	}
+
+	for _, f := range c.Fields {
+		if shouldSkipField(c, f) {
+			continue
+		}
+		f = g.fieldWithOverride(c, f)
+
+		value, ok, err := mockValue(c, f)
+		if err != nil {
+			return fmt.Errorf("mocks: %s.%s: %w", c.Name, f.Name, err)
+		}
+		if ok {
+			entries = append(entries, sanitizeFieldName(f.Name)+": "+value)
+		}
+	}
+	entries = append(entries, "...overrides")
+
+	w.WL("")
+	w.WL("/**")
+	w.WL(fmt.Sprintf(" * Builds a %q record as returned by the API, overrides replace the generated values", c.Name))
+	w.WL(" */")
+	w.WL(fmt.Sprintf("export const make%s = (", n.pascalSingular))
+	w.WL(fmt.Sprintf("\toverrides: Partial<%s> = {}", record))
+	w.WL(fmt.Sprintf("): %s => ({", record))
+	w.Indent()
+	for i, e := range entries {
+		if i < len(entries)-1 {
+			e += ","
+		}
+		w.WL(e)
+	}
+	w.Dedent()
+	w.WL("});")
+
+	return nil
+}
+
+// mockValue returns the TypeScript expression generating a value of f accepted by its
+// response schema, ok is false for types without a schema.
+func mockValue(c schema.Collection, f schema.Field) (value string, ok bool, err error) {
+	switch f.Type {
+	case schema.FieldText:
+		value, err = mockText(c, f)
+		return value, err == nil, err
+	case schema.FieldNumber:
+		value, err = mockNumber(f)
+		return value, err == nil, err
+	case schema.FieldBool:
+		return "bool()", true, nil
+	case schema.FieldEmail:
+		return fmt.Sprintf("`${text(5, 10)}@${pick(%s)}`", tsArray(mockDomains(f))), true, nil
+	case schema.FieldURL:
+		return fmt.Sprintf("`https://${pick(%s)}/${text(5, 10)}`", tsArray(mockDomains(f))), true, nil
+	case schema.FieldEditor:
+		return "`<p>${text(10, 40)}</p>`", true, nil
+	case schema.FieldDate, schema.FieldAutoDate:
+		return "date()", true, nil
+	case schema.FieldJSON:
+		return "'{}'", true, nil
+	case schema.FieldGeoPoint:
+		return "{ lon: float(-180, 180), lat: float(-90, 90) }", true, nil
+	case schema.FieldSelect:
+		return mockSelect(f), true, nil
+	case schema.FieldFile:
+		return mockFile(f), true, nil
+	case schema.FieldRelation:
+		if f.MaxSelect != nil && *f.MaxSelect == 1 {
+			return "id()", true, nil
+		}
+		return fmt.Sprintf("many(1, %d, id)", maxItems(f)), true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+func mockText(c schema.Collection, f schema.Field) (string, error) {
+	lo, hi := 1, 0
+	if f.Min != nil && *f.Min > 0 {
+		lo = *f.Min
+	}
+	if f.Max != nil && *f.Max > 0 {
+		hi = *f.Max
+	}
+
+	if f.Pattern != nil && *f.Pattern != "" {
+		r := rand.New(rand.NewPCG(fieldSeed(c, f), 0))
+		samples := make([]string, 0, patternSamples)
+		for range patternSamples {
+			s, err := fake.Pattern(r, *f.Pattern, lo, hi)
+			if err != nil {
+				return "", err
+			}
+			samples = append(samples, s)
+		}
+		return fmt.Sprintf("pick(%s)", tsArray(samples)), nil
+	}
+
+	// keep the values short, the limits only bound them
+	upper := lo + 20
+	if hi > 0 && hi < upper {
+		upper = hi
+	}
+	return fmt.Sprintf("text(%d, %d)", lo, max(lo, upper)), nil
+}
+
+func mockNumber(f schema.Field) (string, error) {
+	lo, hi := 0.0, 100.0
+	switch {
+	case f.MinValue != nil && f.MaxValue != nil:
+		lo, hi = *f.MinValue, *f.MaxValue
+	case f.MinValue != nil:
+		lo, hi = *f.MinValue, *f.MinValue+100
+	case f.MaxValue != nil:
+		lo, hi = *f.MaxValue-100, *f.MaxValue
+	}
+
+	if f.NoDecimals {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+		if lo > hi {
+			return "", fmt.Errorf("no integer between the min %v and max %v", *f.MinValue, *f.MaxValue)
+		}
+		return fmt.Sprintf("int(%s, %s)", formatNumber(lo), formatNumber(hi)), nil
+	}
+	return fmt.Sprintf("float(%s, %s)", formatNumber(lo), formatNumber(hi)), nil
+}
+
+// mockSelect returns an array, the response schema of selects is an array for single selects too.
+func mockSelect(f schema.Field) string {
+	if len(f.Values) == 0 {
+		return "[]"
+	}
+	if f.MaxSelect != nil && *f.MaxSelect == 1 {
+		return fmt.Sprintf("[pick(%s)]", tsArray(f.Values))
+	}
+
+	most := len(f.Values)
+	if f.MaxSelect != nil && *f.MaxSelect < most {
+		most = *f.MaxSelect
+	}
+	return fmt.Sprintf("pickMany(%s, 1, %d)", tsArray(f.Values), most)
+}
+
+// mockFile returns file names, except for required single files whose response schema is a File.
+func mockFile(f schema.Field) string {
+	ext := "'txt'"
+	if f.MimeTypes != nil && len(*f.MimeTypes) > 0 {
+		exts := make([]string, 0, len(*f.MimeTypes))
+		for _, t := range *f.MimeTypes {
+			exts = append(exts, mimeExtension(t))
+		}
+		ext = fmt.Sprintf("pick(%s)", tsArray(exts))
+	}
+
+	switch {
+	case f.MaxSelect == nil || *f.MaxSelect != 1:
+		return fmt.Sprintf("many(1, %d, () => fileName(%s))", maxItems(f), ext)
+	case f.Required:
+		return fmt.Sprintf("new File([], fileName(%s))", ext)
+	default:
+		return fmt.Sprintf("fileName(%s)", ext)
+	}
+}
+
+// mimeExtension derives a file extension from a mime type, e.g. image/svg+xml -> svg.
+func mimeExtension(mimeType string) string {
+	_, sub, _ := strings.Cut(mimeType, "/")
+	sub, _, _ = strings.Cut(sub, "+")
+
+	ext := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToLower(sub))
+	if ext == "" {
+		return "bin"
+	}
+	return ext
+}
+
+// mockDomains returns the hosts URLs are generated for, respecting the domain lists of f.
+func mockDomains(f schema.Field) []string {
+	var only, except []string
+	if f.OnlyDomains != nil {
+		only = *f.OnlyDomains
+	}
+	if f.ExceptDomains != nil {
+		except = *f.ExceptDomains
+	}
+	return fake.Domains(only, except)
+}
+
+// maxItems bounds the number of generated values of multiple fields.
+func maxItems(f schema.Field) int {
+	if f.MaxSelect != nil && *f.MaxSelect > 0 && *f.MaxSelect < 3 {
+		return *f.MaxSelect
+	}
+	return 3
+}
+
+func fieldSeed(c schema.Collection, f schema.Field) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(c.Name + "." + f.Name))
+	return h.Sum64()
+}
+
+func tsArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = valibot.Quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -2,50 +2,64 @@ package gen
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
-	"github.com/zenaxo/valibase/internal/utils"
+	"github.com/zenaxo/valibase/schema"
 )
 
-func createUpdateExports(collectionName string, cType collectionType) string {
-	pascalSingular := utils.ToPascalCase(utils.ToSingular(collectionName))
-	lowerCamelSingular := utils.ToLowerCamelCase(utils.ToSingular(collectionName))
-
+// createUpdateExports writes the create and update schemas, checks are piped after both.
+func createUpdateExports(n collNameParts, cType schema.CollectionType, checks []string) string {
 	createFn := "createBaseSchema"
 	updateFn := "updateBaseSchema"
-	if cType == CollectionAuth {
+	if cType == schema.CollectionAuth {
 		createFn = "createAuthSchema"
 		updateFn = "updateAuthSchema"
 	}
 
+	create := fmt.Sprintf("%s(%sInput)", createFn, n.lowerCamelSingular)
+	update := fmt.Sprintf("%s(%sInput)", updateFn, n.lowerCamelSingular)
+	if len(checks) > 0 {
+		pipe := ",\n\t" + strings.Join(checks, ",\n\t") + "\n"
+		create = "v.pipe(\n\t" + create + pipe + ")"
+		update = "v.pipe(\n\t" + update + pipe + ")"
+	}
+
 	return fmt.Sprintf(`
 /**
  * Create/Update schemas and their inferred input types for "%[1]s" records.
  */
-export const create%[1]sSchema = %[3]s(%[2]sInput);
-export const update%[1]sSchema = %[4]s(%[2]sInput);
+export const create%[1]sSchema = %[2]s;
+export const update%[1]sSchema = %[3]s;
 
 // Inferred input types from the above schemas
 export type Create%[1]sInput = v.InferOutput<typeof create%[1]sSchema>;
 export type Update%[1]sInput = v.InferOutput<typeof update%[1]sSchema>;
-`, pascalSingular, lowerCamelSingular, createFn, updateFn)
+`, n.pascalSingular, create, update)
 }
 
-func writeRegistry(w *tsw, collectionNames []string) {
+func (g *tsGen) writeRegistry(collectionNames, viewNames []string) {
+	w := g.w
 	w.WL("")
 	w.WL("// Central registry of all generated collection schemas")
 	w.WL("export const registry = {")
 	w.Indent()
 
 	for _, coll := range collectionNames {
-		lowerCamelSingular := utils.ToLowerCamelCase(utils.ToSingular(coll))
-		pascalSingular := utils.ToPascalCase(utils.ToSingular(coll))
+		lowerCamelSingular := g.names[coll].lowerCamelSingular
+		pascalSingular := g.names[coll].pascalSingular
 
 		w.WL(fmt.Sprintf("// Schemas for the %q collection", coll))
 		w.WL(fmt.Sprintf("%s: {", coll))
 		w.Indent()
-		w.WL(fmt.Sprintf("response: %sResponse,", lowerCamelSingular))
-		w.WL(fmt.Sprintf("create: create%sSchema,", pascalSingular))
-		w.WL(fmt.Sprintf("update: update%sSchema", pascalSingular))
+		if slices.Contains(viewNames, coll) {
+			w.WL(fmt.Sprintf("response: %sResponse", lowerCamelSingular))
+		} else {
+			w.WL(fmt.Sprintf("response: %sResponse,", lowerCamelSingular))
+			w.WL(fmt.Sprintf("create: create%sSchema,", pascalSingular))
+			w.WL(fmt.Sprintf("update: update%sSchema,", pascalSingular))
+			w.WL(fmt.Sprintf("formMeta: %sFormMeta", lowerCamelSingular))
+		}
 		w.Dedent()
 		w.WL("},")
 		w.WL("")
@@ -57,15 +71,31 @@ func writeRegistry(w *tsw, collectionNames []string) {
 	w.WL("export type CollectionsMap = typeof registry;")
 	w.WL("export type CollectionNameKey = keyof CollectionsMap;")
 	w.WL("")
+	w.WL("// View collections are read-only, they have no create/update schemas")
+	w.WL("export type ViewCollectionNameKey = " + unionOrNever(viewNames) + ";")
+	w.WL("export type WritableCollectionNameKey = Exclude<CollectionNameKey, ViewCollectionNameKey>;")
+	w.WL("")
 	w.WL("// Helper type map: collection name -> strongly typed record")
 	w.WL("export type ResponseTypes = {")
 	w.Indent()
 
 	for _, coll := range collectionNames {
-		pascalSingular := utils.ToPascalCase(utils.ToSingular(coll))
-		w.WL(fmt.Sprintf("%s: %s;", coll, pascalSingular))
+		w.WL(fmt.Sprintf("%s: %s;", coll, g.names[coll].pascalSingular))
 	}
 
 	w.Dedent()
 	w.WL("};")
 }
+
+// unionOrNever returns the union of the quoted names, never if there are none.
+func unionOrNever(names []string) string {
+	if len(names) == 0 {
+		return "never"
+	}
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	return strings.Join(quoted, " | ")
+}
@@ -0,0 +1,61 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zenaxo/valibase/schema"
+)
+
+// rulesDoc returns the JSDoc describing who can perform each operation on c.
+func rulesDoc(c schema.Collection) string {
+	var b strings.Builder
+	b.WriteString("/**\n")
+	fmt.Fprintf(&b, " * Access rules of %q\n", c.Name)
+	for _, r := range c.Rules() {
+		fmt.Fprintf(&b, " * - %s: %s\n", r.Operation, ruleDescription(r))
+	}
+	b.WriteString(" */\n")
+	return b.String()
+}
+
+func ruleDescription(r schema.Rule) string {
+	switch r.Access() {
+	case schema.AccessSuperuser:
+		return "superusers only"
+	case schema.AccessPublic:
+		return "public"
+	default:
+		// keep the expression from closing the comment
+		expr := strings.ReplaceAll(*r.Expr, "*/", "*\\/")
+		expr = strings.Join(strings.Fields(expr), " ")
+		return "`" + expr + "`"
+	}
+}
+
+// writeRules writes the collectionRules map of the access of every operation.
+func (g *tsGen) writeRules(collections []schema.Collection) {
+	w := g.w
+	w.WL("")
+	w.WL("// Who can perform each operation: 'public' (empty rule), 'restricted' (rule expression)")
+	w.WL("// or 'superuser' (no rule), e.g. to hide buttons of locked operations.")
+	w.WL("// View collections are read-only, they have no create, update and delete entries")
+	w.WL("export type RuleAccess = 'public' | 'restricted' | 'superuser';")
+	w.WL("export type CollectionRules = Record<'list' | 'view', RuleAccess> &")
+	w.WL("\tPartial<Record<'create' | 'update' | 'delete', RuleAccess>>;")
+	w.WL("")
+	w.WL("export const collectionRules = {")
+	w.Indent()
+
+	for _, c := range collections {
+		rules := c.Rules()
+		props := make([]string, len(rules))
+		for i, r := range rules {
+			props[i] = fmt.Sprintf("%s: '%s'", r.Operation, r.Access())
+		}
+		w.WL(fmt.Sprintf("%s: { %s },", c.Name, strings.Join(props, ", ")))
+	}
+
+	w.Dedent()
+	w.WL("} as const satisfies Record<CollectionNameKey, CollectionRules>;")
+}
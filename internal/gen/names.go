@@ -1,22 +1,112 @@
 package gen
 
-import "github.com/zenaxo/valibase/internal/utils"
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/zenaxo/valibase/internal/utils"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// Naming derives the TypeScript identifiers of a collection.
+type Naming interface {
+	// Singular returns the singular form of a collection name, e.g. "todos" -> "todo".
+	Singular(collectionName string) string
+	// Pascal converts s to PascalCase, used for types and the Collections keys.
+	Pascal(s string) string
+	// Camel converts s to lowerCamelCase, used for the schema constants.
+	Camel(s string) string
+}
+
+// DefaultNaming is the Naming used when none is configured.
+type DefaultNaming struct{}
+
+func (DefaultNaming) Singular(collectionName string) string { return utils.ToSingular(collectionName) }
+func (DefaultNaming) Pascal(s string) string                { return utils.ToPascalCase(s) }
+func (DefaultNaming) Camel(s string) string                 { return utils.ToLowerCamelCase(s) }
 
 type collNameParts struct {
 	collectionName     string
+	key                string // key in the Collections const
 	singular           string
 	lowerCamelSingular string
 	pascalSingular     string
 }
 
-func nameParts(collectionName string) collNameParts {
-	singular := utils.ToSingular(collectionName)
+func nameParts(naming Naming, collectionName, singular string) collNameParts {
 	return collNameParts{
 		collectionName:     collectionName,
+		key:                naming.Pascal(collectionName),
 		singular:           singular,
-		lowerCamelSingular: utils.ToLowerCamelCase(singular),
-		pascalSingular:     utils.ToPascalCase(singular),
+		lowerCamelSingular: naming.Camel(singular),
+		pascalSingular:     naming.Pascal(singular),
+	}
+}
+
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// resolveNames returns the identifiers of every collection keyed by collection name.
+//
+// overrides maps a collection name to the singular name used instead of
+// naming.Singular, e.g. {"news": "NewsItem"}. Two collections resolving to the
+// same identifier (e.g. "post" and "posts") are reported as an error.
+func resolveNames(collections []schema.Collection, naming Naming, overrides map[string]string) (map[string]collNameParts, error) {
+	if naming == nil {
+		naming = DefaultNaming{}
+	}
+
+	var errs []error
+
+	known := make(map[string]struct{}, len(collections))
+	for _, c := range collections {
+		known[c.Name] = struct{}{}
+	}
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		if _, ok := known[name]; !ok {
+			errs = append(errs, fmt.Errorf("name override for unknown collection %q", name))
+		}
+	}
+
+	names := make(map[string]collNameParts, len(collections))
+
+	// identifier -> first collection using it, per namespace
+	types := map[string]string{}
+	consts := map[string]string{}
+	keys := map[string]string{}
+
+	claim := func(seen map[string]string, ident, collectionName string) bool {
+		if !identifierRegex.MatchString(ident) {
+			errs = append(errs, fmt.Errorf("collection %q: %q is not a valid identifier", collectionName, ident))
+			return false
+		}
+		if other, ok := seen[ident]; ok {
+			errs = append(errs, fmt.Errorf("collections %q and %q both generate %q, add a name override", other, collectionName, ident))
+			return false
+		}
+		seen[ident] = collectionName
+		return true
 	}
+
+	for _, c := range collections {
+		singular, ok := overrides[c.Name]
+		if !ok {
+			singular = naming.Singular(c.Name)
+		}
+
+		n := nameParts(naming, c.Name, singular)
+		// the camel form usually collides along with the Pascal one, report the pair once
+		if claim(types, n.pascalSingular, c.Name) {
+			claim(consts, n.lowerCamelSingular, c.Name)
+		}
+		claim(keys, n.key, c.Name)
+
+		names[c.Name] = n
+	}
+
+	return names, errors.Join(errs...)
 }
 
 func sanitizeFieldName(name string) string {
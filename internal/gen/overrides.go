@@ -0,0 +1,215 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zenaxo/valibase/internal/valibot"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// FieldOverride customizes the schemas generated for a field.
+type FieldOverride struct {
+	// Type replaces the type the schemas are generated from, e.g. for view
+	// columns PocketBase infers as json.
+	Type schema.FieldType
+
+	// Response replaces the response schema of the field.
+	Response string
+
+	// Input replaces the input schema of the field.
+	Input string
+
+	// Append pipes valibot actions after the input schema, e.g. "v.toLowerCase()",
+	// inside the optional wrapper of optional fields.
+	Append []string
+}
+
+// Check is a cross-field validation appended to the create and update schemas of a collection.
+type Check struct {
+	// Field is the field the issue is reported on.
+	Field string
+
+	// Fn is the TypeScript check function, it receives the whole input,
+	// e.g. "(i) => !i.end || !i.start || i.end > i.start".
+	Fn string
+
+	// Message is the issue message, added to the message catalog under Key.
+	Message string
+
+	// Key is the message key of Message, "collection.field" if empty.
+	Key string
+}
+
+// messageKeyRegex matches the keys checks may add to the message catalog.
+var messageKeyRegex = regexp.MustCompile(`^[\w.-]+$`)
+
+// fieldTypes are the types fieldSchemas generates schemas for.
+var fieldTypes = map[schema.FieldType]struct{}{
+	schema.FieldText:     {},
+	schema.FieldFile:     {},
+	schema.FieldNumber:   {},
+	schema.FieldBool:     {},
+	schema.FieldEmail:    {},
+	schema.FieldURL:      {},
+	schema.FieldDate:     {},
+	schema.FieldAutoDate: {},
+	schema.FieldSelect:   {},
+	schema.FieldJSON:     {},
+	schema.FieldRelation: {},
+	schema.FieldEditor:   {},
+	schema.FieldGeoPoint: {},
+}
+
+// validateOverrides reports overrides and checks referring to collections or
+// fields that are not generated. Hidden fields are generated with admin set.
+func validateOverrides(collections []schema.Collection, fields map[string]FieldOverride, checks map[string][]Check, admin bool) error {
+	// fields of the regular schemas, and the ones overrides may refer to
+	public := make(map[string]map[string]struct{}, len(collections))
+	overridable := make(map[string]map[string]struct{}, len(collections))
+	views := map[string]struct{}{}
+	for _, c := range collections {
+		if c.Type == schema.CollectionView {
+			views[c.Name] = struct{}{}
+		}
+		public[c.Name] = map[string]struct{}{}
+		overridable[c.Name] = map[string]struct{}{}
+		for _, f := range c.Fields {
+			if !shouldSkipField(c, f) {
+				public[c.Name][f.Name] = struct{}{}
+				overridable[c.Name][f.Name] = struct{}{}
+			} else if admin && adminOnlyField(f) {
+				overridable[c.Name][f.Name] = struct{}{}
+			}
+		}
+	}
+
+	var errs []error
+
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		collection, field, ok := strings.Cut(key, ".")
+		if !ok {
+			errs = append(errs, fmt.Errorf("field override %q: expected collection.field", key))
+			continue
+		}
+		if _, ok := overridable[collection][field]; !ok {
+			errs = append(errs, fmt.Errorf("field override %q: no such field", key))
+		}
+
+		o := fields[key]
+		if _, ok := fieldTypes[o.Type]; o.Type != "" && !ok {
+			errs = append(errs, fmt.Errorf("field override %q: unknown type %q", key, o.Type))
+		}
+		if _, ok := views[collection]; ok && (o.Input != "" || len(o.Append) > 0) {
+			errs = append(errs, fmt.Errorf("field override %q: view collections have no input schema", key))
+		}
+	}
+
+	keys := map[string]struct{}{}
+	for _, key := range valibot.MessageKeys() {
+		keys[key] = struct{}{}
+	}
+
+	for _, collection := range slices.Sorted(maps.Keys(checks)) {
+		if _, ok := public[collection]; !ok {
+			errs = append(errs, fmt.Errorf("checks: unknown collection %q", collection))
+			continue
+		}
+		if _, ok := views[collection]; ok {
+			errs = append(errs, fmt.Errorf("checks: %q is a view collection, it has no create/update schemas", collection))
+			continue
+		}
+		for _, c := range checks[collection] {
+			if _, ok := public[collection][c.Field]; !ok {
+				errs = append(errs, fmt.Errorf("checks: %q has no field %q", collection, c.Field))
+			}
+			if strings.TrimSpace(c.Fn) == "" {
+				errs = append(errs, fmt.Errorf("checks: %s.%s: empty check function", collection, c.Field))
+			}
+			if c.Message == "" {
+				continue
+			}
+
+			key := checkKey(collection, c)
+			if !messageKeyRegex.MatchString(key) {
+				errs = append(errs, fmt.Errorf("checks: %s.%s: invalid message key %q", collection, c.Field, key))
+			}
+			if _, ok := keys[key]; ok {
+				errs = append(errs, fmt.Errorf("checks: %s.%s: message key %q is already used, set Key", collection, c.Field, key))
+			}
+			keys[key] = struct{}{}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fieldWithOverride returns f with the type of its override, if any.
+func (g *tsGen) fieldWithOverride(c schema.Collection, f schema.Field) schema.Field {
+	if o, ok := g.opts.Fields[c.Name+"."+f.Name]; ok && o.Type != "" {
+		f.Type = o.Type
+	}
+	return f
+}
+
+// applyOverride applies the override of f, if any, to its generated schemas.
+func (g *tsGen) applyOverride(c schema.Collection, f schema.Field, view, input string) (string, string) {
+	o, ok := g.opts.Fields[c.Name+"."+f.Name]
+	if !ok {
+		return view, input
+	}
+
+	if o.Response != "" {
+		view = o.Response
+	}
+	if o.Input != "" {
+		input = o.Input
+	}
+	// actions on generated inputs are piped by emitField
+	if o.Input != "" && len(o.Append) > 0 {
+		input = g.v.Pipe(slices.Concat([]string{o.Input}, o.Append)...)
+	}
+
+	return view, input
+}
+
+// checkKey returns the message key of c, a check of collectionName.
+func checkKey(collectionName string, c Check) string {
+	if c.Key != "" {
+		return c.Key
+	}
+	return collectionName + "." + c.Field
+}
+
+// checkMessages returns the messages of the checks, keyed by checkKey.
+func checkMessages(checks map[string][]Check) valibot.Messages {
+	messages := valibot.Messages{}
+	for collection, cs := range checks {
+		for _, c := range cs {
+			if c.Message != "" {
+				messages[checkKey(collection, c)] = c.Message
+			}
+		}
+	}
+	return messages
+}
+
+// collectionChecks returns the v.forward actions of the checks of a collection.
+func (g *tsGen) collectionChecks(collectionName string) []string {
+	checks := g.opts.Checks[collectionName]
+
+	actions := make([]string, 0, len(checks))
+	for _, c := range checks {
+		check := c.Fn
+		if c.Message != "" {
+			check += ", " + g.v.Message(checkKey(collectionName, c))
+		}
+		actions = append(actions, fmt.Sprintf("v.forward(%s, [%s])", g.v.Check(check), valibot.Quote(c.Field)))
+	}
+
+	return actions
+}
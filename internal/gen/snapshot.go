@@ -0,0 +1,66 @@
+package gen
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zenaxo/valibase/schema"
+)
+
+const schemaHeaderPrefix = "// @valibase-schema "
+
+// schemaHeader returns a comment line embedding collections as gzipped, base64 encoded JSON.
+func schemaHeader(collections []schema.Collection) (string, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	if err := schema.WriteJSON(zw, collections); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	return schemaHeaderPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()) + "\n", nil
+}
+
+// ReadSchemaHeader returns the collections embedded in a file generated with
+// Emitter.EmbedSchema. ok is false if the file has no embedded schema.
+func ReadSchemaHeader(r io.Reader) (collections []schema.Collection, ok bool, err error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+		if !strings.HasPrefix(line, schemaHeaderPrefix) {
+			continue
+		}
+
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, schemaHeaderPrefix))
+		if err != nil {
+			return nil, false, fmt.Errorf("decode schema header: %w", err)
+		}
+
+		zr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, false, fmt.Errorf("decode schema header: %w", err)
+		}
+		defer zr.Close()
+
+		collections, err := schema.ReadJSON(zr)
+		if err != nil {
+			return nil, false, err
+		}
+		return collections, true, nil
+	}
+
+	return nil, false, sc.Err()
+}
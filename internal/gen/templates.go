@@ -2,7 +2,15 @@ package gen
 
 import (
 	_ "embed"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
 	"strings"
+	"text/template"
+
+	"github.com/zenaxo/valibase/internal/valibot"
+	"github.com/zenaxo/valibase/schema"
 )
 
 //go:embed templates/imports.ts.txt
@@ -14,9 +22,150 @@ var helpersRaw string
 //go:embed templates/tail.ts.txt
 var tailRaw string
 
-func imports() string     { return normalize(importsRaw) }
-func typeHelpers() string { return normalize(helpersRaw) }
-func tail() string        { return normalize(tailRaw) }
+// DefaultTemplates returns the built-in templates keyed by name ("imports", "helpers" and "tail").
+func DefaultTemplates() map[string]string {
+	return map[string]string{
+		"imports": normalize(importsRaw),
+		"helpers": normalize(helpersRaw),
+		"tail":    normalize(tailRaw),
+	}
+}
+
+// Template is a replacement for a built-in template, given as Text or as the file Path of FS.
+type Template struct {
+	Text string
+	FS   fs.FS
+	Path string
+}
+
+// Templates replaces the built-in templates, nil keeps the built-in one
+// and an empty Template drops the section.
+type Templates struct {
+	Imports *Template
+	Helpers *Template
+	Tail    *Template
+}
+
+// templateFuncs are bound to the state of each run, these stubs only allow parsing
+var templateFuncs = template.FuncMap{
+	"msg":        func(string, ...string) string { return "" },
+	"typeName":   func(string) string { return "" },
+	"schemaName": func(string) string { return "" },
+}
+
+// the built-in templates are parsed once
+var (
+	importsTemplate = mustParse("imports", importsRaw)
+	helpersTemplate = mustParse("helpers", helpersRaw)
+	tailTemplate    = mustParse("tail", tailRaw)
+)
+
+func mustParse(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(templateFuncs).Parse(normalize(text)))
+}
+
+// Message is an entry of the message catalog, Value is quoted.
+type Message struct {
+	Key   string
+	Value string
+
+	// Prop is Key as an object property, quoted if it isn't an identifier.
+	Prop string
+}
+
+// TemplateData is the data templates are executed with.
+type TemplateData struct {
+	Collections []schema.Collection
+
+	// MessageKeys reports whether messages are emitted as lookups, Messages then
+	// holds the catalog with quoted values.
+	MessageKeys bool
+	Messages    []Message
+
+	// RuleAware hides the write methods of superuser-only operations from the typed client.
+	RuleAware bool
+}
+
+// renderTemplate renders the override t if set, builtin otherwise.
+//
+// Besides the data, templates can use:
+//
+//	{{msg "key"}}          the message expression of key, further arguments are
+//	                       name/expression pairs of parameters only known at runtime
+//	{{typeName "posts"}}   the type name of a collection, e.g. Post
+//	{{schemaName "posts"}} the schema name prefix of a collection, e.g. post
+func (g *tsGen) renderTemplate(builtin *template.Template, t *Template, data TemplateData) (string, error) {
+	var tmpl *template.Template
+	if t == nil {
+		clone, err := builtin.Clone()
+		if err != nil {
+			return "", err
+		}
+		tmpl = clone
+	} else {
+		text := t.Text
+		if t.FS != nil {
+			b, err := fs.ReadFile(t.FS, t.Path)
+			if err != nil {
+				return "", err
+			}
+			text = string(b)
+		}
+
+		parsed, err := template.New(builtin.Name()).Funcs(templateFuncs).Parse(normalize(text))
+		if err != nil {
+			return "", err
+		}
+		tmpl = parsed
+	}
+
+	tmpl.Funcs(template.FuncMap{
+		"msg": func(key string, params ...string) string {
+			var args []valibot.Arg
+			for i := 0; i+1 < len(params); i += 2 {
+				args = append(args, valibot.RuntimeArg(params[i], params[i+1]))
+			}
+			return g.v.Message(key, args...)
+		},
+		"typeName": func(collectionName string) (string, error) {
+			n, ok := g.names[collectionName]
+			if !ok {
+				return "", fmt.Errorf("unknown collection %q", collectionName)
+			}
+			return n.pascalSingular, nil
+		},
+		"schemaName": func(collectionName string) (string, error) {
+			n, ok := g.names[collectionName]
+			if !ok {
+				return "", fmt.Errorf("unknown collection %q", collectionName)
+			}
+			return n.lowerCamelSingular, nil
+		},
+	})
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// templateData returns the data the templates of a run are executed with.
+func (g *tsGen) templateData(collections []schema.Collection) TemplateData {
+	messages := g.v.Messages()
+	entries := make([]Message, 0, len(messages))
+	for _, key := range slices.Sorted(maps.Keys(messages)) {
+		entries = append(entries, Message{Key: key, Value: valibot.Quote(messages[key]), Prop: sanitizeFieldName(key)})
+	}
+
+	return TemplateData{
+		Collections: collections,
+		MessageKeys: g.v.MessageKeys(),
+		Messages:    entries,
+		RuleAware:   g.opts.RuleAware,
+	}
+}
 
 func normalize(s string) string {
 	s = strings.ReplaceAll(s, "\r\n", "\n")
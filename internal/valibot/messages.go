@@ -0,0 +1,224 @@
+package valibot
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Messages is a catalog of validation messages keyed by rule.
+// Messages reference the parameters of their rule as {name}, e.g.
+//
+//	"minLength": "Input must be at least {min} characters"
+type Messages map[string]string
+
+// DefaultMessages returns a copy of the built-in catalog.
+func DefaultMessages() Messages {
+	return maps.Clone(defaultMessages)
+}
+
+var defaultMessages = Messages{
+	"minLength":               "Input must be at least {min} characters",
+	"maxLength":               "Input must be at most {max} characters",
+	"length":                  "Input must be exactly {length}",
+	"minValue":                "Input must be greater than {greaterThan}",
+	"maxValue":                "Input must be lower than {lowerThan}",
+	"integer":                 "Only integers are allowed.",
+	"regex":                   "Invalid format",
+	"email":                   "Please enter a valid email address",
+	"url":                     "The url is badly formatted.",
+	"domainUrl":               "The url is badly formatted",
+	"mimeType":                "Please select one of the following file types: {types}",
+	"maxSize":                 "Please select a file smaller than {size}",
+	"onlyDomains":             "The URL must be one of: {domains}",
+	"exceptDomains":           "The URL must not be any one of: {domains}",
+	"passwordMismatch":        "Passwords do not match",
+	"passwordConfirmRequired": "Please confirm your new password",
+	"oldPasswordRequired":     "Old password is required to change password",
+	"identityRequired":        "Please enter your email or username",
+	"passwordRequired":        "Please enter your password",
+	"passwordMinLength":       "Password must be at least 8 characters",
+	"tokenRequired":           "Please enter the token",
+}
+
+// valibotMessages are emitted as literals only when overridden, by default the
+// message of Valibot itself is kept.
+var valibotMessages = map[string]bool{
+	"passwordMinLength": true,
+	"tokenRequired":     true,
+}
+
+// messageParams lists the placeholders each rule provides.
+var messageParams = map[string][]string{
+	"minLength":     {"min"},
+	"maxLength":     {"max"},
+	"length":        {"length"},
+	"minValue":      {"min", "greaterThan"},
+	"maxValue":      {"max", "lowerThan"},
+	"mimeType":      {"types"},
+	"maxSize":       {"size"},
+	"onlyDomains":   {"domains"},
+	"exceptDomains": {"domains"},
+}
+
+var placeholderRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+// MessageKeys returns the rule keys of the catalog, sorted.
+func MessageKeys() []string {
+	return slices.Sorted(maps.Keys(defaultMessages))
+}
+
+// MergeMessages returns the default catalog extended by extra (the messages of
+// cross-field checks), overridden by custom.
+// It fails on unknown keys and on placeholders the rule does not provide.
+func MergeMessages(custom, extra Messages) (Messages, error) {
+	merged := DefaultMessages()
+	maps.Copy(merged, extra)
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(custom)) {
+		if _, ok := merged[key]; !ok {
+			errs = append(errs, fmt.Errorf("unknown message key %q", key))
+			continue
+		}
+
+		msg := custom[key]
+		for _, m := range placeholderRegex.FindAllStringSubmatch(msg, -1) {
+			if !slices.Contains(messageParams[key], m[1]) {
+				errs = append(errs, fmt.Errorf("message %q: unknown placeholder {%s}", key, m[1]))
+			}
+		}
+
+		merged[key] = msg
+	}
+
+	return merged, errors.Join(errs...)
+}
+
+// Arg is a message parameter.
+//
+// Text is the value substituted into literal messages. If Text is empty, the
+// value is only known at runtime and Expr, a TypeScript expression, is interpolated instead.
+type Arg struct {
+	Name string
+	Text string
+	Expr string
+}
+
+// StaticArg returns an Arg whose value is known at generation time.
+func StaticArg(name string, value any) Arg {
+	text := fmt.Sprint(value)
+
+	expr := text
+	if _, ok := value.(string); ok {
+		expr = Quote(text)
+	}
+
+	return Arg{Name: name, Text: text, Expr: expr}
+}
+
+// RuntimeArg returns an Arg evaluated by the generated code, e.g. "domains.join(', ')".
+func RuntimeArg(name, expr string) Arg {
+	return Arg{Name: name, Expr: expr}
+}
+
+// Message returns the TypeScript expression of the message for key.
+//
+// By default it is a string literal (or a template literal when args are only
+// known at runtime). With keys enabled, it is a call to the generated msg helper
+// so the message can be resolved by an i18n library, e.g. msg('minLength', { min: 3 }).
+//
+// It is empty for messages Valibot provides by default which are not overridden.
+func (v Builder) Message(key string, args ...Arg) string {
+	return v.message(key, Quote, args)
+}
+
+// doubleQuotedMessage is Message with double quoted literals, used by the length,
+// value and integer rules.
+func (v Builder) doubleQuotedMessage(key string, args ...Arg) string {
+	return v.message(key, doubleQuote, args)
+}
+
+func (v Builder) message(key string, quote func(string) string, args []Arg) string {
+	if v.keys {
+		if len(args) == 0 {
+			return fmt.Sprintf("msg('%s')", key)
+		}
+
+		props := make([]string, len(args))
+		for i, a := range args {
+			props[i] = a.Name + ": " + a.Expr
+		}
+		return fmt.Sprintf("msg('%s', { %s })", key, strings.Join(props, ", "))
+	}
+
+	msg, ok := v.messages[key]
+	if !ok {
+		msg = defaultMessages[key]
+	}
+	if valibotMessages[key] && msg == defaultMessages[key] {
+		return ""
+	}
+
+	byName := make(map[string]Arg, len(args))
+	runtime := false
+	for _, a := range args {
+		byName[a.Name] = a
+		if a.Text == "" {
+			runtime = true
+		}
+	}
+
+	if !runtime {
+		return quote(placeholderRegex.ReplaceAllStringFunc(msg, func(p string) string {
+			if a, ok := byName[p[1:len(p)-1]]; ok {
+				return a.Text
+			}
+			return p
+		}))
+	}
+
+	// template literal, static text has to be escaped
+	var b strings.Builder
+	b.WriteByte('`')
+	last := 0
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(msg, -1) {
+		b.WriteString(escapeTemplate(msg[last:loc[0]]))
+		if a, ok := byName[msg[loc[2]:loc[3]]]; ok {
+			if a.Text != "" {
+				b.WriteString(escapeTemplate(a.Text))
+			} else {
+				b.WriteString("${" + a.Expr + "}")
+			}
+		} else {
+			b.WriteString(escapeTemplate(msg[loc[0]:loc[1]]))
+		}
+		last = loc[1]
+	}
+	b.WriteString(escapeTemplate(msg[last:]))
+	b.WriteByte('`')
+
+	return b.String()
+}
+
+var (
+	quoteEscaper       = strings.NewReplacer("\\", "\\\\", "'", "\\'", "\n", "\\n", "\r", "\\r")
+	doubleQuoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, `\"`, "\n", "\\n", "\r", "\\r")
+	templateEscaper    = strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${")
+)
+
+// Quote returns s as a single quoted TypeScript string.
+func Quote(s string) string {
+	return "'" + quoteEscaper.Replace(s) + "'"
+}
+
+func doubleQuote(s string) string {
+	return `"` + doubleQuoteEscaper.Replace(s) + `"`
+}
+
+func escapeTemplate(s string) string {
+	return templateEscaper.Replace(s)
+}
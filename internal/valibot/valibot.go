@@ -7,18 +7,40 @@ import (
 	"github.com/zenaxo/valibase/internal/utils"
 )
 
-type valibot struct {
+// Builder writes valibot expressions, with messages taken from its catalog.
+type Builder struct {
 	FileName string // fileNameSchema
 	File     string // fileSchema
 	Relation string // recordIdSchema
 	Password string // passwordSchema
+
+	messages Messages
+	keys     bool
 }
 
-var V = valibot{
-	FileName: "fileNameSchema",
-	File:     "fileSchema",
-	Relation: "recordIdSchema",
-	Password: "passwordSchema",
+// V is a Builder using the default messages.
+var V = New(nil, false)
+
+// New returns a Builder using messages (see MergeMessages), the defaults if nil.
+// With keys set, messages are emitted as msg(key, params) lookups instead of literals.
+func New(messages Messages, keys bool) Builder {
+	if messages == nil {
+		messages = defaultMessages
+	}
+
+	return Builder{
+		FileName: "fileNameSchema",
+		File:     "fileSchema",
+		Relation: "recordIdSchema",
+		Password: "passwordSchema",
+		messages: messages,
+		keys:     keys,
+	}
+}
+
+// MessageKeys reports whether messages are emitted as lookups, see New.
+func (v Builder) MessageKeys() bool {
+	return v.keys
 }
 
 type callProps struct {
@@ -29,7 +51,7 @@ type callProps struct {
 
 func callWithMessage(props *callProps) string {
 	if props.message != "" {
-		return fmt.Sprintf(`v.%s(%v, "%s")`, props.fn, props.arg, props.message)
+		return fmt.Sprintf("v.%s(%v, %s)", props.fn, props.arg, props.message)
 	}
 	return fmt.Sprintf("v.%s(%v)", props.fn, props.arg)
 }
@@ -53,7 +75,7 @@ Example:
 
 	v.string()
 */
-func (v valibot) String() string {
+func (v Builder) String() string {
 	return call("string", "")
 }
 
@@ -64,7 +86,7 @@ Example:
 
 	v.number()
 */
-func (v valibot) Number() string {
+func (v Builder) Number() string {
 	return call("number", "")
 }
 
@@ -75,7 +97,7 @@ Example:
 
 	v.number()
 */
-func (v valibot) Boolean() string {
+func (v Builder) Boolean() string {
 	return call("boolean", "")
 }
 
@@ -86,7 +108,7 @@ Example:
 
 	v.url()
 */
-func (v valibot) URL() string {
+func (v Builder) URL() string {
 	return call("url", "")
 }
 
@@ -106,19 +128,19 @@ Example:
 		v.brand('URL')
 	)
 */
-func (v valibot) URLSchema() string {
+func (v Builder) URLSchema() string {
 	return "urlSchema"
 }
 
 /*
-Creates an email schema with a custom error message
+Creates an email schema with the "email" message
 
 Example:
 
-	v.email('The email is badly formatted')
+	v.email("Please enter a valid email address")
 */
-func (v valibot) Email() string {
-	return call("email", "'The url is badly formatted'")
+func (v Builder) Email() string {
+	return call("email", v.Message("email"))
 }
 
 /*
@@ -129,7 +151,7 @@ Example:
 
 	v.object({ id: v.string() })
 */
-func (v valibot) Object(shape string) string {
+func (v Builder) Object(shape string) string {
 	return call("object", shape)
 }
 
@@ -141,7 +163,7 @@ todoSchema ->
 
 	v.array(todoSchema)
 */
-func (v valibot) Array(schemas ...string) string {
+func (v Builder) Array(schemas ...string) string {
 	return call("array", createModsString(schemas...))
 }
 
@@ -152,7 +174,7 @@ Example:
 
 	v.union([aSchema, bSchema])
 */
-func (v valibot) Union(schemas ...string) string {
+func (v Builder) Union(schemas ...string) string {
 	return call("union", fmt.Sprintf("[%s]", createModsString(schemas...)))
 }
 
@@ -163,7 +185,7 @@ Example:
 
 	v.literal('a')
 */
-func (v valibot) Literal(schemas ...string) string {
+func (v Builder) Literal(schemas ...string) string {
 	return call("literal", createModsString(schemas...))
 }
 
@@ -174,7 +196,7 @@ Example:
 
 	v.picklist('a', 'b', 'c')
 */
-func (v valibot) Picklist(values ...string) string {
+func (v Builder) Picklist(values ...string) string {
 	return call("picklist", createModsString(values...))
 }
 
@@ -186,7 +208,7 @@ todoSchema ->
 
 	v.optional(todoSchema)
 */
-func (v valibot) Optional(schemas ...string) string {
+func (v Builder) Optional(schemas ...string) string {
 	return call("optional", createModsString(schemas...))
 }
 
@@ -197,7 +219,7 @@ Example:
 
 	v.string(), v.minLength(10) -> v.pipe(v.string(), v.minLength(10))
 */
-func (v valibot) Pipe(schemas ...string) string {
+func (v Builder) Pipe(schemas ...string) string {
 	return call("pipe", createModsString(schemas...))
 }
 
@@ -208,7 +230,7 @@ Example:
 
 	v.transform((value) => value.trim())
 */
-func (v valibot) Transform(transformation string) string {
+func (v Builder) Transform(transformation string) string {
 	return call("transform", transformation)
 }
 
@@ -219,7 +241,7 @@ Example:
 
 	v.lazy(() => userSchema)
 */
-func (v valibot) Lazy(schemas ...string) string {
+func (v Builder) Lazy(schemas ...string) string {
 	return call("lazy", createModsString(schemas...))
 }
 
@@ -230,7 +252,7 @@ Example:
 
 	v.check((input) => input.length > 0)
 */
-func (v valibot) Check(fn string) string {
+func (v Builder) Check(fn string) string {
 	return call("check", fn)
 }
 
@@ -241,7 +263,7 @@ Example:
 
 	v.brand('UserId')
 */
-func (v valibot) Brand(name string) string {
+func (v Builder) Brand(name string) string {
 	return call("brand", fmt.Sprintf("'%s'", name))
 }
 
@@ -253,7 +275,7 @@ Example:
 
 	v.nonEmpty()
 */
-func (v valibot) NonEmpty() string {
+func (v Builder) NonEmpty() string {
 	return call("nonEmpty", "")
 }
 
@@ -266,11 +288,11 @@ Example:
 
 	v.minLength(10)
 */
-func (v valibot) MinLength(n int) string {
+func (v Builder) MinLength(n int) string {
 	props := callProps{
 		fn:      "minLength",
 		arg:     n,
-		message: fmt.Sprintf("Input must be at least %v characters", n),
+		message: v.doubleQuotedMessage("minLength", StaticArg("min", n)),
 	}
 	return callWithMessage(&props)
 }
@@ -284,11 +306,11 @@ Example:
 
 	v.maxLength(10)
 */
-func (v valibot) MaxLength(n int) string {
+func (v Builder) MaxLength(n int) string {
 	props := callProps{
 		fn:      "maxLength",
 		arg:     n,
-		message: fmt.Sprintf("Input must be at most %v characters", n),
+		message: v.doubleQuotedMessage("maxLength", StaticArg("max", n)),
 	}
 	return callWithMessage(&props)
 }
@@ -302,11 +324,11 @@ Example:
 
 	v.length(10)
 */
-func (v valibot) Length(n int) string {
+func (v Builder) Length(n int) string {
 	props := callProps{
 		fn:      "length",
 		arg:     n,
-		message: fmt.Sprintf("Input must be exactly %v", n),
+		message: v.doubleQuotedMessage("length", StaticArg("length", n)),
 	}
 	return callWithMessage(&props)
 }
@@ -320,11 +342,11 @@ Example:
 
 	v.minValue(10)
 */
-func (v valibot) MinValue(n float64) string {
+func (v Builder) MinValue(n float64) string {
 	props := callProps{
 		fn:      "minValue",
 		arg:     n,
-		message: fmt.Sprintf("Input must be greater than %v", n-1),
+		message: v.doubleQuotedMessage("minValue", StaticArg("min", n), StaticArg("greaterThan", n-1)),
 	}
 	return callWithMessage(&props)
 }
@@ -338,11 +360,11 @@ Example:
 
 	v.maxValue(10)
 */
-func (v valibot) MaxValue(n float64) string {
+func (v Builder) MaxValue(n float64) string {
 	props := callProps{
 		fn:      "maxValue",
 		arg:     n,
-		message: fmt.Sprintf("Input must be lower than %v", n+1),
+		message: v.doubleQuotedMessage("maxValue", StaticArg("max", n), StaticArg("lowerThan", n+1)),
 	}
 	return callWithMessage(&props)
 }
@@ -357,7 +379,7 @@ Example:
 
 	v.value(10)
 */
-func (v valibot) Value(n float64) string {
+func (v Builder) Value(n float64) string {
 	return call("value", n)
 }
 
@@ -368,8 +390,8 @@ Example:
 
 	v.regex(new RegExp(`^[\w][\w\.\-]*$`))
 */
-func (v valibot) Pattern(p string) string {
-	return call("regex", "/"+p+"/, 'Invalid format'")
+func (v Builder) Pattern(p string) string {
+	return call("regex", "/"+p+"/, "+v.Message("regex"))
 }
 
 /*
@@ -388,7 +410,7 @@ Example:
 		v.transform((input) => (input !== '' ? input : undefined))
 	);
 */
-func (v valibot) OptionalTextResponse(schemas ...string) string {
+func (v Builder) OptionalTextResponse(schemas ...string) string {
 	var s string
 	if len(schemas) == 0 {
 		s = v.String()
@@ -406,7 +428,7 @@ hello, world ->
 
 	stringEnum("hello", "world")
 */
-func (v valibot) StringEnum(opts []string) string {
+func (v Builder) StringEnum(opts []string) string {
 	return "stringEnum(" + utils.ToQuotedStringArray(opts) + ")"
 }
 
@@ -418,7 +440,7 @@ facebook.com, instagram.com ->
 
 	onlyDomains("facebook.com", "instagram.com")
 */
-func (v valibot) OnlyDomains(domains []string) string {
+func (v Builder) OnlyDomains(domains []string) string {
 	return "onlyDomains(" + utils.ToQuotedStringArray(domains) + ")"
 }
 
@@ -430,7 +452,7 @@ facebook.com, instagram.com ->
 
 	exceptDomains("facebook.com", "instagram.com")
 */
-func (v valibot) ExceptDomains(domains []string) string {
+func (v Builder) ExceptDomains(domains []string) string {
 	return "exceptDomains(" + utils.ToQuotedStringArray(domains) + ")"
 }
 
@@ -442,7 +464,7 @@ Example:
 	// mock schema (todoExpandSchema)
 	withExpand(todoExpandSchema)
 */
-func (v valibot) WithExpand(schemas ...string) string {
+func (v Builder) WithExpand(schemas ...string) string {
 	return "withExpand(" + createModsString(schemas...) + ")"
 }
 
@@ -454,7 +476,7 @@ Example:
 	// mock collection (todos)
 	systemFieldsSchema(Collections.Todos)
 */
-func (v valibot) SystemFields(collection string) string {
+func (v Builder) SystemFields(collection string) string {
 	return "systemFieldsSchema(Collections." + utils.ToPascalCase(collection) + ")"
 }
 
@@ -466,7 +488,7 @@ Example:
 	// mock types
 	v.mimeTypes([]string{"image/jpeg", "image/png"})
 */
-func (v valibot) MimeTypes(types []string) string {
+func (v Builder) MimeTypes(types []string) string {
 	quoted := utils.ToQuotedStringArray(types)
 
 	var capitalized []string
@@ -479,8 +501,7 @@ func (v valibot) MimeTypes(types []string) string {
 		}
 	}
 
-	errorMsg := "'Please select one of the following file types: " +
-		strings.Join(capitalized, " or ") + "'"
+	errorMsg := v.Message("mimeType", StaticArg("types", strings.Join(capitalized, " or ")))
 
 	return call("mimeType", "["+quoted+"], "+errorMsg)
 }
@@ -491,11 +512,11 @@ MaxSize returns a maxSize schema for a file with a human readable label
 Example:
 10485760 (10 MB) ->
 
-	v.maxSize(10 * 1024 * 1024, "Please select a file smaller than 10MB")
+	v.maxSize(10 * 1024 * 1024, "Please select a file smaller than 10 MB")
 */
-func (v valibot) MaxSize(size int64) string {
+func (v Builder) MaxSize(size int64) string {
 	expr, label := utils.SizeExpression(size)
-	return call("maxSize", expr+", 'Please select a file smaller than "+label+"'")
+	return call("maxSize", expr+", "+v.Message("maxSize", StaticArg("size", label)))
 }
 
 /*
@@ -503,7 +524,7 @@ Def:
 
 	export const isoDateStringSchema = v.pipe(v.string(), v.isoTimestamp(), v.brand('Date'));
 */
-func (v valibot) IsoDate() string {
+func (v Builder) IsoDate() string {
 	return "isoDateStringSchema"
 }
 
@@ -512,7 +533,7 @@ Def:
 
 	export const isoAutoDateStringSchema = v.pipe(v.string(), v.isoTimestamp(), v.brand('AutoDate'));
 */
-func (v valibot) IsoAutoDate() string {
+func (v Builder) IsoAutoDate() string {
 	return "isoAutoDateStringSchema"
 }
 
@@ -521,7 +542,7 @@ Def:
 
 	export const jsonSchema = v.pipe(v.string(), v.brand('JSON'));
 */
-func (v valibot) JSON() string {
+func (v Builder) JSON() string {
 	return "jsonSchema"
 }
 
@@ -530,7 +551,7 @@ Def:
 
 	export const editorSchema = v.pipe(v.string(), v.brand('Editor'));
 */
-func (v valibot) Editor() string {
+func (v Builder) Editor() string {
 	return "editorSchema"
 }
 
@@ -545,7 +566,7 @@ Def:
 		v.brand('GeoPoint')
 	);
 */
-func (v valibot) GeoPoint() string {
+func (v Builder) GeoPoint() string {
 	return "geoPointSchema"
 }
 
@@ -564,10 +585,15 @@ Def:
 
 	export const emailSchema = v.pipe(v.string(), v.email(), v.brand('Email'));
 */
-func (v valibot) EmailSchema() string {
+func (v Builder) EmailSchema() string {
 	return "emailSchema"
 }
 
-func (v valibot) Integer() string {
-	return call("integer", `"Only integers are allowed."`)
+func (v Builder) Integer() string {
+	return call("integer", v.doubleQuotedMessage("integer"))
+}
+
+// Messages returns the catalog of the builder.
+func (v Builder) Messages() Messages {
+	return v.messages
 }
@@ -0,0 +1,376 @@
+package parity
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zenaxo/valibase/internal/fake"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// RelatedID stands for an existing record of the related collection in relation values,
+// Check replaces it with the id of a record it created. It is a valid record id for the
+// generated schemas.
+const RelatedID = "relatedrecordid"
+
+// password is the password of the auth payloads.
+const password = "parity-password"
+
+// Case is a create payload and whether the generated create schema accepts it.
+type Case struct {
+	// Name describes the case, e.g. "title: max+1".
+	Name string `json:"name"`
+	// Field is the field the case varies, empty for the base payload.
+	Field string         `json:"field,omitempty"`
+	Data  map[string]any `json:"data"`
+	// Valid reports whether the generated Valibot schema accepts Data, set by Expect.
+	Valid bool `json:"valid"`
+}
+
+// WriteCases writes cases as JSON.
+func WriteCases(w io.Writer, cases []Case) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cases)
+}
+
+// ReadCases reads cases written by WriteCases, e.g. with expectations produced
+// by the generated schemas.
+func ReadCases(r io.Reader) ([]Case, error) {
+	var cases []Case
+	if err := json.NewDecoder(r).Decode(&cases); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+// GenerateCases returns a base payload of c and boundary cases varying one field of it.
+//
+// Valid is left unset, the expectations come from running the payloads through the
+// generated schemas with Expect. File fields are not covered, collections with a required
+// file field fail.
+func GenerateCases(c schema.Collection) ([]Case, error) {
+	if c.Type == schema.CollectionView {
+		return nil, fmt.Errorf("parity: %s: view collections have no create schema", c.Name)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(c.Name))
+	g := caseGen{r: rand.New(rand.NewPCG(h.Sum64(), 0))}
+
+	base := make(map[string]any)
+	var inputs []schema.Field
+	for _, f := range c.Fields {
+		switch {
+		case f.Type == schema.FieldFile:
+			if f.Required {
+				return nil, fmt.Errorf("parity: %s.%s: required file fields are not supported", c.Name, f.Name)
+			}
+			continue
+		case f.Name == "id" || f.Type == schema.FieldAutoDate || f.System && f.Hidden:
+			continue
+		}
+
+		value, ok, err := g.valid(f)
+		if err != nil {
+			return nil, fmt.Errorf("parity: %s.%s: %w", c.Name, f.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		base[f.Name] = value
+
+		// hidden fields are not part of the generated schemas, they are only
+		// set so the base payload is valid for PocketBase
+		if !f.Hidden {
+			inputs = append(inputs, f)
+		}
+	}
+
+	if c.Type == schema.CollectionAuth {
+		base["password"] = password
+		base["passwordConfirm"] = password
+	}
+
+	cases := []Case{{Name: "valid", Data: base}}
+	for _, f := range inputs {
+		for _, v := range g.variants(f) {
+			data := maps.Clone(base)
+			if v.missing {
+				delete(data, f.Name)
+			} else {
+				data[f.Name] = v.value
+			}
+			cases = append(cases, Case{
+				Name:  f.Name + ": " + v.name,
+				Field: f.Name,
+				Data:  data,
+			})
+		}
+	}
+
+	if c.Type == schema.CollectionAuth {
+		data := maps.Clone(base)
+		data["password"] = "short12"
+		data["passwordConfirm"] = "short12"
+		cases = append(cases, Case{Name: "password: too short", Field: "password", Data: data})
+	}
+
+	return cases, nil
+}
+
+// variant is a value of a field, or its absence.
+type variant struct {
+	name    string
+	value   any
+	missing bool
+}
+
+type caseGen struct {
+	r *rand.Rand
+}
+
+// valid returns a value of f accepted by the generated schema and PocketBase,
+// ok is false for fields left out of the base payload.
+func (g caseGen) valid(f schema.Field) (value any, ok bool, err error) {
+	switch f.Type {
+	case schema.FieldText:
+		s, err := g.text(f, textMin(f), textMax(f))
+		return s, err == nil, err
+	case schema.FieldNumber:
+		return middle(f), true, nil
+	case schema.FieldBool:
+		return true, true, nil
+	case schema.FieldEmail:
+		return "parity@" + domains(f)[0], true, nil
+	case schema.FieldURL:
+		return "https://" + domains(f)[0] + "/parity", true, nil
+	case schema.FieldEditor:
+		return "<p>parity</p>", true, nil
+	case schema.FieldDate:
+		return "2024-01-01T10:00:00.000Z", true, nil
+	case schema.FieldJSON:
+		return `{"parity":true}`, true, nil
+	case schema.FieldGeoPoint:
+		return map[string]any{"lon": 10.5, "lat": 59.9}, true, nil
+	case schema.FieldSelect:
+		if len(f.Values) == 0 {
+			return nil, false, nil
+		}
+		if singleInput(f) {
+			return f.Values[0], true, nil
+		}
+		return []string{f.Values[0]}, true, nil
+	case schema.FieldRelation:
+		if singleInput(f) {
+			return RelatedID, true, nil
+		}
+		return []string{RelatedID}, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// variants returns the boundary cases of f.
+func (g caseGen) variants(f schema.Field) []variant {
+	out := []variant{{name: "missing", missing: true}}
+
+	switch f.Type {
+	case schema.FieldText:
+		out = append(out, g.textVariants(f)...)
+	case schema.FieldNumber:
+		out = append(out, numberVariants(f)...)
+	case schema.FieldBool:
+		out = append(out, variant{name: "false", value: false})
+	case schema.FieldEmail:
+		out = append(out,
+			variant{name: "empty", value: ""},
+			variant{name: "invalid", value: "not-an-email"},
+		)
+		out = append(out, domainVariants(f, func(domain string) string { return "parity@" + domain })...)
+	case schema.FieldURL:
+		out = append(out, variant{name: "invalid", value: "not a url"})
+		out = append(out, domainVariants(f, func(domain string) string { return "https://" + domain + "/x" })...)
+	case schema.FieldDate:
+		out = append(out, variant{name: "invalid", value: "yesterday"})
+	case schema.FieldSelect:
+		out = append(out, selectVariants(f)...)
+	case schema.FieldRelation:
+		out = append(out, relationVariants(f)...)
+	}
+
+	return out
+}
+
+func (g caseGen) textVariants(f schema.Field) []variant {
+	var pattern *regexp.Regexp
+	if f.Pattern != nil && *f.Pattern != "" {
+		// an invalid pattern fails the base payload already
+		pattern, _ = regexp.Compile(*f.Pattern)
+	}
+
+	out := []variant{{name: "empty", value: ""}}
+
+	withLength := func(name string, n int) {
+		if n < 1 {
+			return
+		}
+		if s, err := g.text(f, n, n); err == nil {
+			out = append(out, variant{name: name, value: s})
+		}
+	}
+	if f.Min != nil {
+		withLength("min-1", *f.Min-1)
+		withLength("min", *f.Min)
+	}
+	if f.Max != nil {
+		withLength("max", *f.Max)
+		withLength("max+1", *f.Max+1)
+	}
+
+	if pattern != nil {
+		n := max(textMin(f), 1)
+		for _, c := range []string{"!", " ", "A", "-", "0", "a"} {
+			s := strings.Repeat(c, n)
+			if !pattern.MatchString(s) {
+				out = append(out, variant{name: "pattern miss", value: s})
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+func numberVariants(f schema.Field) []variant {
+	out := []variant{{name: "zero", value: 0}}
+	if f.MinValue != nil {
+		out = append(out,
+			variant{name: "min-1", value: *f.MinValue - 1},
+			variant{name: "min", value: *f.MinValue},
+		)
+	}
+	if f.MaxValue != nil {
+		out = append(out,
+			variant{name: "max", value: *f.MaxValue},
+			variant{name: "max+1", value: *f.MaxValue + 1},
+		)
+	}
+	if f.NoDecimals {
+		out = append(out, variant{name: "decimal", value: middle(f) + 0.5})
+	}
+	return out
+}
+
+func selectVariants(f schema.Field) []variant {
+	if len(f.Values) == 0 {
+		return nil
+	}
+
+	unknown := "parity-unknown"
+	for slices.Contains(f.Values, unknown) {
+		unknown += "-"
+	}
+
+	if singleInput(f) {
+		return []variant{{name: "unknown value", value: unknown}}
+	}
+
+	out := []variant{
+		{name: "unknown value", value: []string{unknown}},
+		{name: "empty", value: []string{}},
+	}
+	if f.MaxSelect != nil && *f.MaxSelect < len(f.Values) {
+		out = append(out, variant{name: "max select+1", value: f.Values[:*f.MaxSelect+1]})
+	}
+	return out
+}
+
+func relationVariants(f schema.Field) []variant {
+	if singleInput(f) {
+		return []variant{{name: "invalid id", value: "abc"}}
+	}
+
+	return []variant{
+		{name: "invalid id", value: []string{"abc"}},
+		{name: "empty", value: []string{}},
+	}
+}
+
+func (g caseGen) text(f schema.Field, lo, hi int) (string, error) {
+	if f.Pattern != nil && *f.Pattern != "" {
+		return fake.Pattern(g.r, *f.Pattern, lo, hi)
+	}
+	return fake.Text(g.r, lo, hi), nil
+}
+
+func textMin(f schema.Field) int {
+	if f.Min != nil && *f.Min > 0 {
+		return *f.Min
+	}
+	return 1
+}
+
+func textMax(f schema.Field) int {
+	lo := textMin(f)
+	if f.Max != nil && *f.Max > 0 {
+		return min(*f.Max, lo+10)
+	}
+	return lo + 10
+}
+
+// middle returns a number between the bounds of f, an integer if f only allows integers.
+func middle(f schema.Field) float64 {
+	lo, hi := 0.0, 100.0
+	switch {
+	case f.MinValue != nil && f.MaxValue != nil:
+		lo, hi = *f.MinValue, *f.MaxValue
+	case f.MinValue != nil:
+		lo, hi = *f.MinValue, *f.MinValue+100
+	case f.MaxValue != nil:
+		lo, hi = *f.MaxValue-100, *f.MaxValue
+	}
+
+	mid := lo + (hi-lo)/2
+	if f.NoDecimals {
+		mid = math.Max(math.Ceil(lo), math.Min(math.Round(mid), math.Floor(hi)))
+	}
+	return mid
+}
+
+// singleInput reports whether the generated input of f is a single value,
+// the generator only emits single selects and relations for a MaxSelect of 1.
+func singleInput(f schema.Field) bool {
+	return f.MaxSelect != nil && *f.MaxSelect == 1
+}
+
+// domainVariants returns the cases of the domain lists of f, address formats a value on domain.
+func domainVariants(f schema.Field, address func(domain string) string) []variant {
+	var out []variant
+	if f.OnlyDomains != nil && len(*f.OnlyDomains) > 0 {
+		out = append(out, variant{name: "other domain", value: address("parity.invalid")})
+	}
+	if f.ExceptDomains != nil && len(*f.ExceptDomains) > 0 {
+		out = append(out, variant{name: "excluded domain", value: address((*f.ExceptDomains)[0])})
+	}
+	return out
+}
+
+func domains(f schema.Field) []string {
+	var only, except []string
+	if f.OnlyDomains != nil {
+		only = *f.OnlyDomains
+	}
+	if f.ExceptDomains != nil {
+		except = *f.ExceptDomains
+	}
+	return fake.Domains(only, except)
+}
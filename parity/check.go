@@ -0,0 +1,283 @@
+package parity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	_ "github.com/pocketbase/pocketbase/migrations"
+	"github.com/zenaxo/valibase/schema"
+	"github.com/zenaxo/valibase/seed"
+)
+
+// Result is the outcome of a case.
+type Result struct {
+	Case
+
+	// ServerValid reports whether PocketBase accepted the payload.
+	ServerValid bool `json:"serverValid"`
+
+	// Error is the validation error of PocketBase, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Match reports whether the generated schema and PocketBase agree on the case.
+func (r Result) Match() bool {
+	return r.Valid == r.ServerValid
+}
+
+func (r Result) String() string {
+	s := fmt.Sprintf("%s: schema valid=%t, PocketBase valid=%t", r.Name, r.Valid, r.ServerValid)
+	if r.Error != "" {
+		s += " (" + r.Error + ")"
+	}
+	return s
+}
+
+// Report lists the results of the cases of a collection.
+type Report struct {
+	Collection string   `json:"collection"`
+	Results    []Result `json:"results"`
+}
+
+// Mismatches returns the results where the generated schema and PocketBase disagree.
+func (r Report) Mismatches() []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if !res.Match() {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// Check validates the payloads of cases as records of the collection named collection
+// of app, and compares the outcome with their expectations.
+//
+// The collection and the collections its relations point to are copied into a temporary
+// app, seeded with a record each so RelatedID can be replaced by an existing id. The
+// records are validated (core.App.Validate) but never saved, so unique indexes aren't checked.
+func Check(app core.App, collection string, cases []Case) (*Report, error) {
+	source, err := app.FindCollectionByNameOrId(collection)
+	if err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+
+	collections, err := withRelated(app, source)
+	if err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "valibase-parity-*")
+	if err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := core.NewBaseApp(core.BaseAppConfig{DataDir: dir})
+	if err := tmp.Bootstrap(); err != nil {
+		return nil, fmt.Errorf("parity: bootstrap: %w", err)
+	}
+	defer tmp.ResetBootstrapState()
+
+	raw, err := exportCollections(collections)
+	if err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+	if err := tmp.ImportCollectionsByMarshaledJSON(raw, false); err != nil {
+		return nil, fmt.Errorf("parity: import collections: %w", err)
+	}
+
+	related, err := seedRelated(tmp, source, collections)
+	if err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+
+	target, err := tmp.FindCollectionByNameOrId(source.Id)
+	if err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+
+	report := &Report{Collection: source.Name, Results: make([]Result, 0, len(cases))}
+	for _, c := range cases {
+		record := core.NewRecord(target)
+		for key, value := range c.Data {
+			if key == "passwordConfirm" {
+				// confirmed by the API forms, not part of the record
+				continue
+			}
+			record.Set(key, replaceRelated(value, related[key]))
+		}
+
+		res := Result{Case: c, ServerValid: true}
+		if err := tmp.Validate(record); err != nil {
+			res.ServerValid = false
+			res.Error = err.Error()
+		}
+		report.Results = append(report.Results, res)
+	}
+
+	return report, nil
+}
+
+// Run generates the cases of each named collection of app, fills their expectations
+// with the runner command (see Expect and WriteRunner), checks them and reports the
+// mismatches as test errors, e.g. in a test of the app:
+//
+//	func TestParity(t *testing.T) {
+//		runner := []string{"node", "--experimental-strip-types", "parity-runner.ts"}
+//		parity.Run(t, app, runner, "posts", "comments")
+//	}
+func Run(t testing.TB, app core.App, runner []string, collections ...string) {
+	t.Helper()
+
+	for _, name := range collections {
+		c, err := app.FindCollectionByNameOrId(name)
+		if err != nil {
+			t.Errorf("parity: %v", err)
+			continue
+		}
+
+		cases, err := GenerateCases(schema.BuildCollections([]*core.Collection{c})[0])
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		cases, err = Expect(runner, c.Name, cases)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		report, err := Check(app, name, cases)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		for _, m := range report.Mismatches() {
+			t.Errorf("%s: %s", report.Collection, m)
+		}
+	}
+}
+
+// withRelated returns c and the collections its relations point to, transitively.
+func withRelated(app core.App, c *core.Collection) ([]*core.Collection, error) {
+	out := []*core.Collection{c}
+	seen := map[string]bool{c.Id: true}
+
+	for i := 0; i < len(out); i++ {
+		for _, f := range out[i].Fields {
+			rel, ok := f.(*core.RelationField)
+			if !ok || seen[rel.CollectionId] {
+				continue
+			}
+			seen[rel.CollectionId] = true
+
+			target, err := app.FindCollectionByNameOrId(rel.CollectionId)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", out[i].Name, rel.Name, err)
+			}
+			out = append(out, target)
+		}
+	}
+
+	return out, nil
+}
+
+// exportCollections marshals collections for an import. OAuth2 is disabled since
+// the client secrets are not exported, it plays no part in record validation.
+func exportCollections(collections []*core.Collection) ([]byte, error) {
+	raw, err := json.Marshal(collections)
+	if err != nil {
+		return nil, err
+	}
+
+	var maps []map[string]any
+	if err := json.Unmarshal(raw, &maps); err != nil {
+		return nil, err
+	}
+	for _, m := range maps {
+		if oauth2, ok := m["oauth2"].(map[string]any); ok {
+			oauth2["enabled"] = false
+			oauth2["providers"] = []any{}
+		}
+	}
+
+	return json.Marshal(maps)
+}
+
+// seedRelated inserts a record into each related collection of c (c included for
+// self-relations) and returns their ids keyed by the name of the relation field.
+func seedRelated(app core.App, c *core.Collection, collections []*core.Collection) (map[string]string, error) {
+	if len(collections) == 1 && !selfRelated(c) {
+		return nil, nil
+	}
+
+	var names []string
+	for i, rc := range collections {
+		if i > 0 || selfRelated(c) {
+			names = append(names, rc.Name)
+		}
+	}
+
+	res, err := seed.Insert(app, seed.Options{Collections: names, Count: 1})
+	if err != nil {
+		return nil, fmt.Errorf("related records: %w", err)
+	}
+
+	byID := make(map[string]string, len(collections))
+	for _, rc := range collections {
+		if ids := res.IDs[rc.Name]; len(ids) > 0 {
+			byID[rc.Id] = ids[0]
+		}
+	}
+
+	related := make(map[string]string)
+	for _, f := range c.Fields {
+		if rel, ok := f.(*core.RelationField); ok {
+			related[rel.Name] = byID[rel.CollectionId]
+		}
+	}
+
+	return related, nil
+}
+
+func selfRelated(c *core.Collection) bool {
+	for _, f := range c.Fields {
+		if rel, ok := f.(*core.RelationField); ok && rel.CollectionId == c.Id {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceRelated replaces RelatedID in value by id.
+func replaceRelated(value any, id string) any {
+	if id == "" {
+		return value
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v == RelatedID {
+			return id
+		}
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = replaceRelated(s, id).(string)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, s := range v {
+			out[i] = replaceRelated(s, id)
+		}
+		return out
+	}
+	return value
+}
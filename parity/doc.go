@@ -0,0 +1,11 @@
+// Package parity checks the generated create schemas against the validation of PocketBase.
+//
+// GenerateCases derives boundary payloads from the collection model (missing values,
+// min-1/max+1 lengths and values, pattern misses, unknown select values, ...). Expect
+// runs them through the generated Valibot schemas with the script written by WriteRunner,
+// recording whether each payload is accepted. Check validates the payloads with
+// core.Record validation in a temporary app and reports where both disagree.
+//
+// Cases are JSON encoded with WriteCases, so the expectations can also be produced
+// separately, e.g. in the CI job of the frontend, and read back with ReadCases.
+package parity
@@ -0,0 +1,67 @@
+package parity
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/zenaxo/valibase/internal/valibot"
+)
+
+//go:embed runner.ts.txt
+var runnerRaw string
+
+var runnerTemplate = template.Must(template.New("runner").Parse(runnerRaw))
+
+// WriteRunner writes the TypeScript script filling the expectations of cases with the
+// generated create schemas, imported from typesImport (e.g. "./database.ts").
+//
+// The script takes the collection name as argument, reads cases written by WriteCases
+// from stdin and writes them with Valid set to stdout, see Expect.
+func WriteRunner(w io.Writer, typesImport string) error {
+	return runnerTemplate.Execute(w, struct{ Import string }{valibot.Quote(typesImport)})
+}
+
+// Expect runs the runner written by WriteRunner on cases of collection and returns them
+// with Valid set by the generated schemas. command runs the runner, the collection name
+// is appended to it, e.g.
+//
+//	[]string{"node", "--experimental-strip-types", "parity-runner.ts"}
+func Expect(command []string, collection string, cases []Case) ([]Case, error) {
+	if len(command) == 0 {
+		return nil, errors.New("parity: empty runner command")
+	}
+
+	var in, out, stderr bytes.Buffer
+	if err := WriteCases(&in, cases); err != nil {
+		return nil, fmt.Errorf("parity: %w", err)
+	}
+
+	cmd := exec.Command(command[0], append(command[1:], collection)...)
+	cmd.Stdin = &in
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("parity: runner: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	expected, err := ReadCases(&out)
+	if err != nil {
+		return nil, fmt.Errorf("parity: runner output: %w", err)
+	}
+	if len(expected) != len(cases) {
+		return nil, fmt.Errorf("parity: runner returned %d cases, expected %d", len(expected), len(cases))
+	}
+	for i := range cases {
+		if expected[i].Name != cases[i].Name {
+			return nil, fmt.Errorf("parity: runner returned case %q in place of %q", expected[i].Name, cases[i].Name)
+		}
+	}
+
+	return expected, nil
+}
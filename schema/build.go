@@ -1,16 +1,24 @@
-package gen
+package schema
 
-import "github.com/pocketbase/pocketbase/core"
+import (
+	"slices"
+	"strings"
 
-func BuildCollections(dbColls []*core.Collection) []collectionRecord {
-	collections := make([]collectionRecord, 0, len(dbColls))
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+)
+
+// BuildCollections converts PocketBase collections into their schema representation,
+// sorted by name like the snapshots of WriteJSON. Nil collections and fields are skipped.
+func BuildCollections(dbColls []*core.Collection) []Collection {
+	collections := make([]Collection, 0, len(dbColls))
 
 	for _, c := range dbColls {
 		if c == nil {
 			continue
 		}
 
-		col := collectionRecord{
+		col := Collection{
 			ID:         c.Id,
 			Type:       toCollectionType(c),
 			Name:       c.Name,
@@ -22,23 +30,37 @@ func BuildCollections(dbColls []*core.Collection) []collectionRecord {
 			DeleteRule: c.DeleteRule,
 		}
 
+		if c.IsAuth() && c.OAuth2.Enabled {
+			col.OAuth2Providers = make([]string, 0, len(c.OAuth2.Providers))
+			for _, p := range c.OAuth2.Providers {
+				col.OAuth2Providers = append(col.OAuth2Providers, p.Name)
+			}
+		}
+
 		fields := c.Fields
-		col.Fields = make([]fieldSchema, 0, len(fields))
+		col.Fields = make([]Field, 0, len(fields))
 
 		for _, f := range fields {
 			if f == nil {
 				continue
 			}
-			col.Fields = append(col.Fields, toFieldSchema(f))
+			col.Fields = append(col.Fields, toField(f))
 		}
 
+		col.Indexes = toIndexes(c.Indexes)
+		markUniqueFields(col.Fields, col.Indexes)
+
 		collections = append(collections, col)
 	}
 
+	slices.SortFunc(collections, func(a, b Collection) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
 	return collections
 }
 
-func toCollectionType(c *core.Collection) collectionType {
+func toCollectionType(c *core.Collection) CollectionType {
 	switch {
 	case c.IsAuth():
 		return CollectionAuth
@@ -49,14 +71,13 @@ func toCollectionType(c *core.Collection) collectionType {
 	}
 }
 
-func toFieldSchema(f core.Field) fieldSchema {
-	out := fieldSchema{
+func toField(f core.Field) Field {
+	out := Field{
 		ID:     f.GetId(),
 		Name:   f.GetName(),
-		Type:   fieldType(f.Type()),
+		Type:   FieldType(f.Type()),
 		System: f.GetSystem(),
 		Hidden: f.GetHidden(),
-		Unique: false, // derive from indexes if needed
 	}
 
 	switch tf := f.(type) {
@@ -83,6 +104,12 @@ func toFieldSchema(f core.Field) fieldSchema {
 
 	case *core.EmailField:
 		out.Required = tf.Required
+		if len(tf.OnlyDomains) > 0 {
+			out.OnlyDomains = ptrStringSlice(tf.OnlyDomains)
+		}
+		if len(tf.ExceptDomains) > 0 {
+			out.ExceptDomains = ptrStringSlice(tf.ExceptDomains)
+		}
 
 	case *core.URLField:
 		out.Required = tf.Required
@@ -149,6 +176,44 @@ func toFieldSchema(f core.Field) fieldSchema {
 	return out
 }
 
+func toIndexes(indexes []string) []Index {
+	out := make([]Index, 0, len(indexes))
+
+	for _, raw := range indexes {
+		idx := dbutils.ParseIndex(raw)
+		if !idx.IsValid() {
+			continue
+		}
+
+		columns := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			columns = append(columns, col.Name)
+		}
+
+		out = append(out, Index{
+			Name:    idx.IndexName,
+			Unique:  idx.Unique && idx.Where == "",
+			Columns: columns,
+		})
+	}
+
+	return out
+}
+
+// markUniqueFields sets Unique on fields covered by a single column unique index.
+func markUniqueFields(fields []Field, indexes []Index) {
+	for _, idx := range indexes {
+		if !idx.Unique || len(idx.Columns) != 1 {
+			continue
+		}
+		for i := range fields {
+			if fields[i].Name == idx.Columns[0] {
+				fields[i].Unique = true
+			}
+		}
+	}
+}
+
 func ptrInt(v int) *int          { return &v }
 func ptrInt64(v int64) *int64    { return &v }
 func ptrString(v string) *string { return &v }
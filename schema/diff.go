@@ -0,0 +1,338 @@
+package schema
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Change is a single difference between two collection models.
+type Change struct {
+	Collection string `json:"collection"`
+	// Field is empty for collection level changes.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	// Breaking is set for changes that can break existing clients,
+	// e.g. removed fields, tightened constraints or dropped select values.
+	Breaking bool `json:"breaking"`
+}
+
+func (c Change) String() string {
+	target := c.Collection
+	if c.Field != "" {
+		target += "." + c.Field
+	}
+
+	prefix := "  "
+	if c.Breaking {
+		prefix = "! "
+	}
+
+	return prefix + target + ": " + c.Message
+}
+
+// Changelog lists the changes between two collection models.
+type Changelog struct {
+	Changes []Change `json:"changes"`
+}
+
+// HasBreaking reports whether any of the changes is breaking.
+func (cl Changelog) HasBreaking() bool {
+	return slices.ContainsFunc(cl.Changes, func(c Change) bool { return c.Breaking })
+}
+
+// Breaking returns only the breaking changes.
+func (cl Changelog) Breaking() []Change {
+	var out []Change
+	for _, c := range cl.Changes {
+		if c.Breaking {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// String returns the changes one per line, breaking changes are prefixed with "!".
+func (cl Changelog) String() string {
+	var b strings.Builder
+	for _, c := range cl.Changes {
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Diff compares two collection models.
+//
+// Collections and fields are matched by id (or by name when the id is empty),
+// so renames are reported as such instead of a removal and an addition.
+func Diff(prev, next []Collection) Changelog {
+	var cl Changelog
+
+	prevByKey := make(map[string]Collection, len(prev))
+	for _, c := range prev {
+		prevByKey[collectionKey(c)] = c
+	}
+
+	seen := make(map[string]struct{}, len(next))
+	for _, c := range next {
+		key := collectionKey(c)
+		seen[key] = struct{}{}
+
+		p, ok := prevByKey[key]
+		if !ok {
+			cl.Changes = append(cl.Changes, Change{Collection: c.Name, Message: "collection added"})
+			continue
+		}
+
+		cl.Changes = append(cl.Changes, diffCollection(p, c)...)
+	}
+
+	for _, c := range prev {
+		if _, ok := seen[collectionKey(c)]; !ok {
+			cl.Changes = append(cl.Changes, Change{Collection: c.Name, Message: "collection removed", Breaking: true})
+		}
+	}
+
+	return cl
+}
+
+func collectionKey(c Collection) string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return "name:" + c.Name
+}
+
+func fieldKey(f Field) string {
+	if f.ID != "" {
+		return f.ID
+	}
+	return "name:" + f.Name
+}
+
+func diffCollection(prev, next Collection) []Change {
+	var changes []Change
+
+	if prev.Name != next.Name {
+		changes = append(changes, Change{
+			Collection: next.Name,
+			Message:    fmt.Sprintf("collection renamed from %q", prev.Name),
+			Breaking:   true,
+		})
+	}
+	if prev.Type != next.Type {
+		changes = append(changes, Change{
+			Collection: next.Name,
+			Message:    fmt.Sprintf("collection type changed from %s to %s", prev.Type, next.Type),
+			Breaking:   true,
+		})
+	}
+
+	prevByKey := make(map[string]Field, len(prev.Fields))
+	for _, f := range prev.Fields {
+		prevByKey[fieldKey(f)] = f
+	}
+
+	seen := make(map[string]struct{}, len(next.Fields))
+	for _, f := range next.Fields {
+		key := fieldKey(f)
+		seen[key] = struct{}{}
+
+		p, ok := prevByKey[key]
+		if !ok {
+			d := fieldDiff{collection: next.Name, field: f.Name}
+			if f.Required {
+				d.add(true, "required field added")
+			} else {
+				d.add(false, "field added")
+			}
+			changes = append(changes, d.changes...)
+			continue
+		}
+
+		changes = append(changes, diffField(next.Name, p, f)...)
+	}
+
+	for _, f := range prev.Fields {
+		if _, ok := seen[fieldKey(f)]; !ok {
+			changes = append(changes, Change{Collection: next.Name, Field: f.Name, Message: "field removed", Breaking: true})
+		}
+	}
+
+	return changes
+}
+
+type fieldDiff struct {
+	collection string
+	field      string
+	changes    []Change
+}
+
+func (d *fieldDiff) add(breaking bool, format string, args ...any) {
+	d.changes = append(d.changes, Change{
+		Collection: d.collection,
+		Field:      d.field,
+		Message:    fmt.Sprintf(format, args...),
+		Breaking:   breaking,
+	})
+}
+
+func diffField(collection string, prev, next Field) []Change {
+	d := fieldDiff{collection: collection, field: next.Name}
+
+	if prev.Name != next.Name {
+		d.add(true, "field renamed from %q", prev.Name)
+	}
+	if prev.Type != next.Type {
+		// constraints of different field types can't be compared
+		d.add(true, "type changed from %s to %s", prev.Type, next.Type)
+		return d.changes
+	}
+
+	// both directions break clients, new inputs are rejected or responses may now be empty
+	switch {
+	case !prev.Required && next.Required:
+		d.add(true, "is now required")
+	case prev.Required && !next.Required:
+		d.add(true, "is no longer required")
+	}
+	diffFlag(&d, prev.Hidden, next.Hidden, "is now hidden", "is no longer hidden")
+	diffFlag(&d, prev.Unique, next.Unique, "is now unique", "is no longer unique")
+	diffFlag(&d, prev.NoDecimals, next.NoDecimals, "now only allows integers", "now allows decimals")
+
+	if !equalPtr(prev.RelationCollectionID, next.RelationCollectionID) {
+		d.add(true, "relation target changed")
+	}
+
+	if prev.Multiple() != next.Multiple() {
+		if next.Multiple() {
+			d.add(true, "changed from a single value to multiple values")
+		} else {
+			d.add(true, "changed from multiple values to a single value")
+		}
+	} else if next.Multiple() {
+		diffUpperBound(&d, "max selected values", prev.MaxSelect, next.MaxSelect)
+	}
+
+	diffLowerBound(&d, "min length", prev.Min, next.Min)
+	diffUpperBound(&d, "max length", prev.Max, next.Max)
+	diffLowerBound(&d, "min value", prev.MinValue, next.MinValue)
+	diffUpperBound(&d, "max value", prev.MaxValue, next.MaxValue)
+	diffUpperBound(&d, "max file size", prev.MaxSize, next.MaxSize)
+
+	switch {
+	case equalPtr(prev.Pattern, next.Pattern):
+	case next.Pattern == nil:
+		d.add(false, "pattern %q removed", *prev.Pattern)
+	case prev.Pattern == nil:
+		d.add(true, "pattern %q added", *next.Pattern)
+	default:
+		d.add(true, "pattern changed from %q to %q", *prev.Pattern, *next.Pattern)
+	}
+
+	removed, added := diffValues(prev.Values, next.Values)
+	for _, v := range removed {
+		d.add(true, "select value %q removed", v)
+	}
+	for _, v := range added {
+		d.add(false, "select value %q added", v)
+	}
+
+	diffAllowList(&d, "mime type", prev.MimeTypes, next.MimeTypes)
+	diffAllowList(&d, "domain", prev.OnlyDomains, next.OnlyDomains)
+
+	removed, added = diffValues(derefSlice(prev.ExceptDomains), derefSlice(next.ExceptDomains))
+	for _, v := range added {
+		d.add(true, "domain %q is now blocked", v)
+	}
+	for _, v := range removed {
+		d.add(false, "domain %q is no longer blocked", v)
+	}
+
+	return d.changes
+}
+
+// diffFlag reports a flipped restriction, setting it is breaking.
+func diffFlag(d *fieldDiff, prev, next bool, setMsg, unsetMsg string) {
+	switch {
+	case !prev && next:
+		d.add(true, "%s", setMsg)
+	case prev && !next:
+		d.add(false, "%s", unsetMsg)
+	}
+}
+
+// diffLowerBound reports changes of a minimum, raising or adding one is breaking.
+func diffLowerBound[T cmp.Ordered](d *fieldDiff, label string, prev, next *T) {
+	switch {
+	case equalPtr(prev, next):
+	case next == nil:
+		d.add(false, "%s %v removed", label, *prev)
+	case prev == nil:
+		d.add(true, "%s %v added", label, *next)
+	default:
+		d.add(*next > *prev, "%s changed from %v to %v", label, *prev, *next)
+	}
+}
+
+// diffUpperBound reports changes of a maximum, lowering or adding one is breaking.
+func diffUpperBound[T cmp.Ordered](d *fieldDiff, label string, prev, next *T) {
+	switch {
+	case equalPtr(prev, next):
+	case next == nil:
+		d.add(false, "%s %v removed", label, *prev)
+	case prev == nil:
+		d.add(true, "%s %v added", label, *next)
+	default:
+		d.add(*next < *prev, "%s changed from %v to %v", label, *prev, *next)
+	}
+}
+
+// diffAllowList reports changes of an optional allow-list where nil allows everything.
+func diffAllowList(d *fieldDiff, label string, prev, next *[]string) {
+	switch {
+	case prev == nil && next == nil:
+	case next == nil:
+		d.add(false, "any %s is now allowed", label)
+	case prev == nil:
+		d.add(true, "only %s %s now allowed", label, strings.Join(*next, ", "))
+	default:
+		removed, added := diffValues(*prev, *next)
+		for _, v := range removed {
+			d.add(true, "%s %q no longer allowed", label, v)
+		}
+		for _, v := range added {
+			d.add(false, "%s %q now allowed", label, v)
+		}
+	}
+}
+
+func diffValues(prev, next []string) (removed, added []string) {
+	for _, v := range prev {
+		if !slices.Contains(next, v) {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range next {
+		if !slices.Contains(prev, v) {
+			added = append(added, v)
+		}
+	}
+	return removed, added
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func derefSlice(s *[]string) []string {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
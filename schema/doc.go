@@ -0,0 +1,6 @@
+// Package schema contains the intermediate representation valibase builds from
+// PocketBase collections.
+//
+// Custom emitters receive this model, which lets them generate their own targets
+// without depending on PocketBase internals.
+package schema
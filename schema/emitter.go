@@ -0,0 +1,14 @@
+package schema
+
+// Emitter renders collections into a generated file.
+type Emitter interface {
+	Emit(collections []Collection) ([]byte, error)
+}
+
+// EmitterFunc adapts a plain function to the Emitter interface.
+type EmitterFunc func(collections []Collection) ([]byte, error)
+
+// Emit calls fn(collections).
+func (fn EmitterFunc) Emit(collections []Collection) ([]byte, error) {
+	return fn(collections)
+}
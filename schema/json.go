@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// snapshotVersion is bumped whenever the JSON layout changes incompatibly.
+const snapshotVersion = 1
+
+type snapshot struct {
+	Version     int          `json:"version"`
+	Collections []Collection `json:"collections"`
+}
+
+// WriteJSON writes collections to w as canonical JSON.
+//
+// Collections are sorted by name and indexes by index name, so the same model
+// always produces the same bytes. Field order is kept as it affects the generated output.
+func WriteJSON(w io.Writer, collections []Collection) error {
+	sorted := make([]Collection, len(collections))
+	copy(sorted, collections)
+
+	slices.SortFunc(sorted, func(a, b Collection) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for i := range sorted {
+		indexes := slices.Clone(sorted[i].Indexes)
+		slices.SortFunc(indexes, func(a, b Index) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		sorted[i].Indexes = indexes
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(snapshot{Version: snapshotVersion, Collections: sorted})
+}
+
+// ReadJSON reads collections previously written with WriteJSON.
+func ReadJSON(r io.Reader) ([]Collection, error) {
+	var s snapshot
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("schema: decode snapshot: %w", err)
+	}
+
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("schema: unsupported snapshot version %d", s.Version)
+	}
+
+	return s.Collections, nil
+}
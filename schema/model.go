@@ -0,0 +1,107 @@
+package schema
+
+// Constraints holds the validation options of a field.
+// Only the options relevant to the field type are set.
+type Constraints struct {
+	// selection / array constraints
+	MaxSelect *int `json:"maxSelect,omitempty"`
+
+	// text / collection constraints
+	Min     *int     `json:"min,omitempty"`
+	Max     *int     `json:"max,omitempty"`
+	Pattern *string  `json:"pattern,omitempty"`
+	Values  []string `json:"values,omitempty"`
+
+	// numeric constraints
+	MinValue   *float64 `json:"minValue,omitempty"`
+	MaxValue   *float64 `json:"maxValue,omitempty"`
+	NoDecimals bool     `json:"noDecimals,omitempty"`
+
+	// file constraints
+	MaxSize   *int64    `json:"maxSize,omitempty"`
+	MimeTypes *[]string `json:"mimeTypes,omitempty"`
+
+	// url/email domain constraints
+	ExceptDomains *[]string `json:"exceptDomains,omitempty"`
+	OnlyDomains   *[]string `json:"onlyDomains,omitempty"`
+}
+
+// FieldType is the PocketBase type of a field.
+type FieldType string
+
+const (
+	FieldText     FieldType = "text"
+	FieldFile     FieldType = "file"
+	FieldNumber   FieldType = "number"
+	FieldBool     FieldType = "bool"
+	FieldEmail    FieldType = "email"
+	FieldURL      FieldType = "url"
+	FieldDate     FieldType = "date"
+	FieldAutoDate FieldType = "autodate"
+	FieldSelect   FieldType = "select"
+	FieldJSON     FieldType = "json"
+	FieldRelation FieldType = "relation"
+	FieldEditor   FieldType = "editor"
+	FieldGeoPoint FieldType = "geoPoint"
+)
+
+// Field describes a single collection field.
+type Field struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	System   bool      `json:"system,omitempty"`
+	Required bool      `json:"required,omitempty"`
+	Unique   bool      `json:"unique,omitempty"`
+	Hidden   bool      `json:"hidden,omitempty"`
+
+	// RelationCollectionID is the id of the target collection of relation fields.
+	RelationCollectionID *string `json:"relationCollectionId,omitempty"`
+
+	Constraints
+}
+
+// Multiple reports whether the field holds several values, PocketBase stores a
+// single one unless MaxSelect is above 1.
+func (f Field) Multiple() bool {
+	return f.MaxSelect != nil && *f.MaxSelect > 1
+}
+
+// CollectionType is the PocketBase type of a collection.
+type CollectionType string
+
+const (
+	CollectionBase CollectionType = "base"
+	CollectionAuth CollectionType = "auth"
+	CollectionView CollectionType = "view"
+)
+
+// Index describes a collection index.
+type Index struct {
+	Name    string   `json:"name"`
+	Unique  bool     `json:"unique,omitempty"`
+	Columns []string `json:"columns"`
+}
+
+// Collection describes a PocketBase collection.
+//
+// A nil rule means the operation is restricted to superusers,
+// an empty rule means it is public.
+type Collection struct {
+	ID     string         `json:"id"`
+	Type   CollectionType `json:"type"`
+	Name   string         `json:"name"`
+	System bool           `json:"system,omitempty"`
+
+	Fields  []Field `json:"fields"`
+	Indexes []Index `json:"indexes,omitempty"`
+
+	ListRule   *string `json:"listRule"`
+	ViewRule   *string `json:"viewRule"`
+	CreateRule *string `json:"createRule"`
+	UpdateRule *string `json:"updateRule"`
+	DeleteRule *string `json:"deleteRule"`
+
+	// OAuth2Providers lists the enabled OAuth2 provider names (auth collections only).
+	OAuth2Providers []string `json:"oauth2Providers,omitempty"`
+}
@@ -0,0 +1,71 @@
+package schema
+
+// Operation is a record API operation guarded by a rule.
+type Operation string
+
+const (
+	OpList   Operation = "list"
+	OpView   Operation = "view"
+	OpCreate Operation = "create"
+	OpUpdate Operation = "update"
+	OpDelete Operation = "delete"
+)
+
+// Access classifies who can perform an operation.
+type Access string
+
+const (
+	// AccessPublic is an empty rule, anyone can perform the operation.
+	AccessPublic Access = "public"
+	// AccessRestricted is a rule expression, e.g. "@request.auth.id != ''".
+	AccessRestricted Access = "restricted"
+	// AccessSuperuser is a nil rule, only superusers can perform the operation.
+	AccessSuperuser Access = "superuser"
+)
+
+// Rule is the API rule of an operation, Expr is nil for superuser-only operations.
+type Rule struct {
+	Operation Operation
+	Expr      *string
+}
+
+// Access returns who can perform the operation.
+func (r Rule) Access() Access {
+	switch {
+	case r.Expr == nil:
+		return AccessSuperuser
+	case *r.Expr == "":
+		return AccessPublic
+	default:
+		return AccessRestricted
+	}
+}
+
+// Rules returns the rules of the collection in list, view, create, update, delete order.
+// View collections are read-only, they only have the list and view rules.
+func (c Collection) Rules() []Rule {
+	if c.Type == CollectionView {
+		return []Rule{
+			{OpList, c.ListRule},
+			{OpView, c.ViewRule},
+		}
+	}
+
+	return []Rule{
+		{OpList, c.ListRule},
+		{OpView, c.ViewRule},
+		{OpCreate, c.CreateRule},
+		{OpUpdate, c.UpdateRule},
+		{OpDelete, c.DeleteRule},
+	}
+}
+
+// Rule returns the rule of op.
+func (c Collection) Rule(op Operation) Rule {
+	for _, r := range c.Rules() {
+		if r.Operation == op {
+			return r
+		}
+	}
+	return Rule{Operation: op}
+}
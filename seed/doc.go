@@ -0,0 +1,6 @@
+// Package seed fills a PocketBase app with fake records built from the collection model.
+//
+// Values satisfy the field constraints (required fields, lengths and patterns, number
+// bounds, select values, max select, domains and file types), and collections are
+// inserted after the collections their relations point to, see Order.
+package seed
@@ -0,0 +1,88 @@
+package seed
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/zenaxo/valibase/internal/fake"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// fileContent generates the content of a file with the given mime type.
+type fileContent struct {
+	ext      string
+	generate func(r *rand.Rand) ([]byte, error)
+}
+
+// fileContents lists the mime types files can be generated for, PocketBase detects
+// the type from the content so it has to be a valid file.
+var fileContents = map[string]fileContent{
+	"text/plain":       {"txt", func(r *rand.Rand) ([]byte, error) { return []byte(fake.Text(r, 20, 80)), nil }},
+	"application/json": {"json", func(r *rand.Rand) ([]byte, error) { return fmt.Appendf(nil, `{"seed":%d}`, r.IntN(1000)), nil }},
+	"image/svg+xml": {"svg", func(r *rand.Rand) ([]byte, error) {
+		return []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="1" height="1"></svg>`), nil
+	}},
+	"image/png": {"png", func(r *rand.Rand) ([]byte, error) {
+		var b bytes.Buffer
+		err := png.Encode(&b, pixel(r))
+		return b.Bytes(), err
+	}},
+	"image/jpeg": {"jpg", func(r *rand.Rand) ([]byte, error) {
+		var b bytes.Buffer
+		err := jpeg.Encode(&b, pixel(r), nil)
+		return b.Bytes(), err
+	}},
+	"image/gif": {"gif", func(r *rand.Rand) ([]byte, error) {
+		var b bytes.Buffer
+		err := gif.Encode(&b, pixel(r), nil)
+		return b.Bytes(), err
+	}},
+}
+
+func pixel(r *rand.Rand) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.RGBA{uint8(r.IntN(256)), uint8(r.IntN(256)), uint8(r.IntN(256)), 255})
+	return img
+}
+
+// file returns a file accepted by f, ok is false if none of its mime types can be generated.
+func file(r *rand.Rand, f schema.Field) (file *filesystem.File, ok bool, err error) {
+	mimeTypes := []string{"text/plain"}
+	if f.MimeTypes != nil && len(*f.MimeTypes) > 0 {
+		mimeTypes = *f.MimeTypes
+	}
+
+	var supported []string
+	for _, t := range mimeTypes {
+		if _, ok := fileContents[t]; ok {
+			supported = append(supported, t)
+		}
+	}
+	slices.Sort(supported)
+	if len(supported) == 0 {
+		return nil, false, nil
+	}
+
+	content := fileContents[supported[r.IntN(len(supported))]]
+	data, err := content.generate(r)
+	if err != nil {
+		return nil, false, err
+	}
+	if f.MaxSize != nil && *f.MaxSize > 0 && int64(len(data)) > *f.MaxSize {
+		return nil, false, nil
+	}
+
+	file, err = filesystem.NewFileFromBytes(data, fake.Text(r, 8, 12)+"."+content.ext)
+	if err != nil {
+		return nil, false, err
+	}
+	return file, true, nil
+}
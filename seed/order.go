@@ -0,0 +1,61 @@
+package seed
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zenaxo/valibase/schema"
+)
+
+// Order returns collections in insert order: each collection comes after the
+// collections its relation fields point to, keeping the given order otherwise.
+//
+// Cycles are broken at optional relations, which are left empty when their target
+// is inserted later. Cycles made of required relations only fail. Relations to
+// collections that are not part of collections and self-relations are ignored.
+func Order(collections []schema.Collection) ([]schema.Collection, error) {
+	included := make(map[string]bool, len(collections))
+	for _, c := range collections {
+		included[c.ID] = true
+	}
+
+	done := make(map[string]bool, len(collections))
+	ready := func(c schema.Collection, requiredOnly bool) bool {
+		for _, f := range c.Fields {
+			if f.Type != schema.FieldRelation || f.RelationCollectionID == nil {
+				continue
+			}
+			target := *f.RelationCollectionID
+			if target == c.ID || !included[target] || done[target] {
+				continue
+			}
+			if f.Required || !requiredOnly {
+				return false
+			}
+		}
+		return true
+	}
+
+	remaining := slices.Clone(collections)
+	out := make([]schema.Collection, 0, len(collections))
+	for len(remaining) > 0 {
+		i := slices.IndexFunc(remaining, func(c schema.Collection) bool { return ready(c, false) })
+		if i < 0 {
+			i = slices.IndexFunc(remaining, func(c schema.Collection) bool { return ready(c, true) })
+		}
+		if i < 0 {
+			names := make([]string, len(remaining))
+			for j, c := range remaining {
+				names[j] = c.Name
+			}
+			return nil, fmt.Errorf("cannot order %s, their required relations form a cycle", strings.Join(names, ", "))
+		}
+
+		out = append(out, remaining[i])
+		done[remaining[i].ID] = true
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,204 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// DefaultCount is the number of records inserted per collection when Options.Count is not set.
+const DefaultCount = 10
+
+// maxAttempts bounds the retries of a record failing validation, e.g. on a unique value collision.
+const maxAttempts = 5
+
+// existingLimit bounds the existing records relations can point to, per collection.
+const existingLimit = 200
+
+// Options configures Insert.
+// Zero values are valid and will use defaults.
+type Options struct {
+	// Collections are the names of the collections to seed.
+	// If empty, every base and auth collection except the system ones is seeded.
+	Collections []string
+
+	// Count is the number of records inserted per collection, DefaultCount if <= 0.
+	Count int
+
+	// Seed seeds the random values, the same seed generates the same records
+	// for the same collections and existing records.
+	Seed uint64
+
+	// Password is the password of the auth records, a random one if empty.
+	Password string
+}
+
+// Result lists the inserted records.
+type Result struct {
+	// IDs are the ids of the inserted records by collection name.
+	IDs map[string][]string
+}
+
+// Insert generates valid fake records and saves them to app in a single transaction,
+// in the order returned by Order. Relations point to the records inserted before
+// and to existing records of their collection.
+func Insert(app core.App, opts ...Options) (*Result, error) {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Count <= 0 {
+		o.Count = DefaultCount
+	}
+
+	dbColls, err := app.FindAllCollections()
+	if err != nil {
+		return nil, fmt.Errorf("seed: FindAllCollections: %w", err)
+	}
+	all := schema.BuildCollections(dbColls)
+
+	selected, err := selectCollections(all, o.Collections)
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+
+	ordered, err := Order(selected)
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+
+	byID := make(map[string]*core.Collection, len(dbColls))
+	for _, c := range dbColls {
+		byID[c.Id] = c
+	}
+
+	res := &Result{IDs: make(map[string][]string, len(ordered))}
+
+	err = app.RunInTransaction(func(txApp core.App) error {
+		g := values{
+			r:   rand.New(rand.NewPCG(o.Seed, o.Seed)),
+			ids: make(map[string][]string),
+		}
+
+		if err := loadExisting(txApp, all, ordered, g.ids); err != nil {
+			return err
+		}
+
+		for _, c := range ordered {
+			for range o.Count {
+				id, err := insertRecord(txApp, byID[c.ID], c, g, o.Password)
+				if err != nil {
+					return fmt.Errorf("%s: %w", c.Name, err)
+				}
+				g.ids[c.ID] = append(g.ids[c.ID], id)
+				res.IDs[c.Name] = append(res.IDs[c.Name], id)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+
+	return res, nil
+}
+
+// selectCollections returns the collections named in names, or the default ones if empty.
+func selectCollections(all []schema.Collection, names []string) ([]schema.Collection, error) {
+	if len(names) == 0 {
+		var out []schema.Collection
+		for _, c := range all {
+			if !c.System && c.Type != schema.CollectionView {
+				out = append(out, c)
+			}
+		}
+		return out, nil
+	}
+
+	var (
+		out  []schema.Collection
+		errs []error
+	)
+	for _, name := range names {
+		i := slices.IndexFunc(all, func(c schema.Collection) bool { return c.Name == name })
+		switch {
+		case i < 0:
+			errs = append(errs, fmt.Errorf("unknown collection %q", name))
+		case all[i].Type == schema.CollectionView:
+			errs = append(errs, fmt.Errorf("view collection %q can't be seeded", name))
+		default:
+			out = append(out, all[i])
+		}
+	}
+
+	return out, errors.Join(errs...)
+}
+
+// loadExisting adds the ids of existing records of the relation targets of collections to ids.
+func loadExisting(app core.App, all, collections []schema.Collection, ids map[string][]string) error {
+	loaded := make(map[string]bool)
+	for _, c := range collections {
+		for _, f := range c.Fields {
+			if f.Type != schema.FieldRelation || f.RelationCollectionID == nil || loaded[*f.RelationCollectionID] {
+				continue
+			}
+			target := *f.RelationCollectionID
+			loaded[target] = true
+
+			if !slices.ContainsFunc(all, func(c schema.Collection) bool { return c.ID == target }) {
+				continue
+			}
+
+			records, err := app.FindRecordsByFilter(target, "", "id", existingLimit, 0)
+			if err != nil {
+				return fmt.Errorf("existing records of %s: %w", target, err)
+			}
+			for _, r := range records {
+				ids[target] = append(ids[target], r.Id)
+			}
+		}
+	}
+	return nil
+}
+
+// insertRecord saves a record of c with generated values, retrying with new
+// values when it fails validation.
+func insertRecord(app core.App, dbColl *core.Collection, c schema.Collection, g values, password string) (string, error) {
+	var lastErr error
+	for range maxAttempts {
+		record := core.NewRecord(dbColl)
+
+		for _, f := range c.Fields {
+			if skipField(f) {
+				continue
+			}
+			value, ok, err := g.value(f)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", f.Name, err)
+			}
+			if ok {
+				record.Set(f.Name, value)
+			}
+		}
+
+		if c.Type == schema.CollectionAuth {
+			if password != "" {
+				record.SetPassword(password)
+			} else {
+				record.SetRandomPassword()
+			}
+		}
+
+		lastErr = app.Save(record)
+		if lastErr == nil {
+			return record.Id, nil
+		}
+	}
+
+	return "", lastErr
+}
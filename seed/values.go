@@ -0,0 +1,195 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/zenaxo/valibase/internal/fake"
+	"github.com/zenaxo/valibase/schema"
+)
+
+// fillRate is the share of optional fields that get a value, out of 10.
+const fillRate = 7
+
+// values generates field values, relations pick from the ids known per collection id.
+type values struct {
+	r   *rand.Rand
+	ids map[string][]string
+}
+
+// skipField reports whether f is set by PocketBase: the id, autodates and
+// hidden system fields like the password and token key of auth collections.
+func skipField(f schema.Field) bool {
+	return f.Name == "id" || f.Type == schema.FieldAutoDate || (f.System && f.Hidden)
+}
+
+// value returns a value of f, ok is false if the field is left empty.
+func (g values) value(f schema.Field) (value any, ok bool, err error) {
+	if !f.Required && g.r.IntN(10) >= fillRate {
+		return nil, false, nil
+	}
+
+	switch f.Type {
+	case schema.FieldText:
+		return g.text(f)
+	case schema.FieldNumber:
+		n, err := g.number(f)
+		return n, err == nil, err
+	case schema.FieldBool:
+		// a required bool has to be true
+		return f.Required || g.r.IntN(2) == 0, true, nil
+	case schema.FieldEmail:
+		return fake.Text(g.r, 5, 10) + "@" + g.pick(g.domains(f)), true, nil
+	case schema.FieldURL:
+		return "https://" + g.pick(g.domains(f)) + "/" + fake.Text(g.r, 5, 10), true, nil
+	case schema.FieldEditor:
+		return "<p>" + fake.Text(g.r, 10, 40) + "</p>", true, nil
+	case schema.FieldDate:
+		return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(g.r.IntN(365*24*60*60)) * time.Second), true, nil
+	case schema.FieldJSON:
+		return map[string]any{"seed": g.r.IntN(1000)}, true, nil
+	case schema.FieldGeoPoint:
+		return types.GeoPoint{Lon: round(g.r.Float64()*360-180, 6), Lat: round(g.r.Float64()*180-90, 6)}, true, nil
+	case schema.FieldSelect:
+		if len(f.Values) == 0 {
+			return nil, false, nil
+		}
+		if f.Multiple() {
+			return g.pickMany(f.Values, *f.MaxSelect), true, nil
+		}
+		return g.pick(f.Values), true, nil
+	case schema.FieldRelation:
+		return g.relation(f)
+	case schema.FieldFile:
+		return g.files(f)
+	default:
+		return nil, false, nil
+	}
+}
+
+func (g values) text(f schema.Field) (any, bool, error) {
+	lo, hi := 1, 0
+	if f.Min != nil && *f.Min > 0 {
+		lo = *f.Min
+	}
+	if f.Max != nil && *f.Max > 0 {
+		hi = *f.Max
+	}
+
+	if f.Pattern != nil && *f.Pattern != "" {
+		s, err := fake.Pattern(g.r, *f.Pattern, lo, hi)
+		if err != nil {
+			return nil, false, err
+		}
+		return s, true, nil
+	}
+
+	upper := lo + 20
+	if hi > 0 && hi < upper {
+		upper = hi
+	}
+	return fake.Text(g.r, lo, upper), true, nil
+}
+
+func (g values) number(f schema.Field) (float64, error) {
+	lo, hi := 0.0, 100.0
+	switch {
+	case f.MinValue != nil && f.MaxValue != nil:
+		lo, hi = *f.MinValue, *f.MaxValue
+	case f.MinValue != nil:
+		lo, hi = *f.MinValue, *f.MinValue+100
+	case f.MaxValue != nil:
+		lo, hi = *f.MaxValue-100, *f.MaxValue
+	}
+
+	if f.NoDecimals {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+		if lo > hi {
+			return 0, fmt.Errorf("no integer between the min %v and max %v", *f.MinValue, *f.MaxValue)
+		}
+		return lo + float64(g.r.IntN(int(hi-lo)+1)), nil
+	}
+
+	n := round(lo+g.r.Float64()*(hi-lo), 2)
+	return math.Min(math.Max(n, lo), hi), nil
+}
+
+func (g values) relation(f schema.Field) (any, bool, error) {
+	if f.RelationCollectionID == nil {
+		return nil, false, nil
+	}
+
+	ids := g.ids[*f.RelationCollectionID]
+	if len(ids) == 0 {
+		if f.Required {
+			return nil, false, fmt.Errorf("no records of the related collection %q to point to", *f.RelationCollectionID)
+		}
+		return nil, false, nil
+	}
+
+	if f.Multiple() {
+		return g.pickMany(ids, *f.MaxSelect), true, nil
+	}
+	return g.pick(ids), true, nil
+}
+
+func (g values) files(f schema.Field) (any, bool, error) {
+	n := 1
+	if f.Multiple() {
+		n = 1 + g.r.IntN(min(*f.MaxSelect, 3))
+	}
+
+	files := make([]any, 0, n)
+	for range n {
+		file, ok, err := file(g.r, f)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			if f.Required {
+				return nil, false, errors.New("cannot generate a file matching the mime types and max size")
+			}
+			return nil, false, nil
+		}
+		files = append(files, file)
+	}
+
+	if f.Multiple() {
+		return files, true, nil
+	}
+	return files[0], true, nil
+}
+
+func (g values) domains(f schema.Field) []string {
+	var only, except []string
+	if f.OnlyDomains != nil {
+		only = *f.OnlyDomains
+	}
+	if f.ExceptDomains != nil {
+		except = *f.ExceptDomains
+	}
+	return fake.Domains(only, except)
+}
+
+func (g values) pick(values []string) string {
+	return values[g.r.IntN(len(values))]
+}
+
+// pickMany returns between 1 and most distinct values.
+func (g values) pickMany(values []string, most int) []string {
+	n := 1 + g.r.IntN(min(most, len(values)))
+	out := make([]string, 0, n)
+	for _, i := range g.r.Perm(len(values))[:n] {
+		out = append(out, values[i])
+	}
+	return out
+}
+
+func round(n float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(n*p) / p
+}